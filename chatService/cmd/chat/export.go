@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Gilf4/grpcChat/chat/internal/app"
+)
+
+// runExport implements the export subcommand:
+//
+//	chat -config <path> export -chat <id> [-out <file>]
+//
+// It writes the chat straight from the database without permission checks,
+// for operators handling legal holds and migrations.
+func runExport(ctx context.Context, application *app.App, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	chatID := fs.Int64("chat", 0, "id of the chat to export")
+	out := fs.String("out", "", "file to write the export to (default chat-<id>.ndjson)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *chatID <= 0 {
+		return errors.New("-chat is required")
+	}
+	if *out == "" {
+		*out = fmt.Sprintf("chat-%d.ndjson", *chatID)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+
+	if err := application.Chat.DumpChat(ctx, *chatID, f); err != nil {
+		f.Close()
+		os.Remove(*out)
+		return err
+	}
+
+	return f.Close()
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -22,19 +23,36 @@ func main() {
 
 	log := setupLogger(cfg.Env)
 
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	application := app.New(ctx, log, cfg)
 
+	switch flag.Arg(0) {
+	case "":
+	case "export":
+		if err := runExport(ctx, application, flag.Args()[1:]); err != nil {
+			log.Error("export failed", "error", err.Error())
+			os.Exit(1)
+		}
+		return
+	default:
+		log.Error("unknown command", "command", flag.Arg(0))
+		os.Exit(1)
+	}
+
 	go func() {
 		application.GRPCServer.MustRun()
 	}()
 
+	go application.Janitor.Run(ctx)
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 
 	signal := <-stop
 
+	cancel()
 	application.GRPCServer.Stop()
 	log.Info("Gracefully stopped", "signal", signal)
 }
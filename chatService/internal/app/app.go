@@ -6,10 +6,16 @@ import (
 
 	grpcapp "github.com/Gilf4/grpcChat/chat/internal/app/grpc"
 	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/Gilf4/grpcChat/chat/internal/grpc/chatgrpc"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/localfs"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
 )
 
 type App struct {
 	GRPCServer *grpcapp.App
+	Chat       *services.ChatService
+	Janitor    *services.RetentionJanitor
 }
 
 func New(
@@ -17,9 +23,98 @@ func New(
 	log *slog.Logger,
 	cfg *config.Config,
 ) *App {
-	grpcApp := grpcapp.New(log, cfg.GRPC.Port)
+	chatRepository, err := postgres.NewChatRepository(ctx, &cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	messageRepository, err := postgres.NewMessageRepository(ctx, &cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	memberRepository, err := postgres.NewMemberRepository(ctx, &cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	reactionRepository, err := postgres.NewReactionRepository(ctx, &cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	attachmentRepository, err := postgres.NewAttachmentRepository(ctx, &cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	blobStorage, err := localfs.NewBlobStorage(cfg.Attachments.Dir)
+	if err != nil {
+		panic(err)
+	}
+
+	mentionRepository, err := postgres.NewMentionRepository(ctx, &cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	pinRepository, err := postgres.NewPinRepository(ctx, &cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	exportRepository, err := postgres.NewExportRepository(ctx, &cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	importRepository, err := postgres.NewImportRepository(ctx, &cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	presenceRepository, err := postgres.NewPresenceRepository(ctx, &cfg.DB)
+	if err != nil {
+		panic(err)
+	}
+
+	chatService := services.NewChatService(
+		log,
+		chatRepository,
+		messageRepository,
+		memberRepository,
+		reactionRepository,
+		attachmentRepository,
+		blobStorage,
+		services.AttachmentLimits{
+			MaxSize:      cfg.Attachments.MaxSize,
+			AllowedTypes: cfg.Attachments.AllowedTypes,
+		},
+		mentionRepository,
+		pinRepository,
+		exportRepository,
+		importRepository,
+		cfg.Import.AllowedUsers,
+	)
+
+	presenceService := services.NewPresenceService(log, presenceRepository)
+
+	hub := chatgrpc.NewHub()
+
+	grpcApp := grpcapp.New(log, cfg.GRPC.Port, cfg.JWTSecret, hub, chatService, presenceService)
+
+	janitor := services.NewRetentionJanitor(
+		log,
+		messageRepository,
+		blobStorage,
+		hub,
+		cfg.Retention.Interval,
+		cfg.Retention.BatchSize,
+	)
 
 	return &App{
 		GRPCServer: grpcApp,
+		Chat:       chatService,
+		Janitor:    janitor,
 	}
 }
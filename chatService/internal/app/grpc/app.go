@@ -6,6 +6,7 @@ import (
 	"net"
 
 	"github.com/Gilf4/grpcChat/chat/internal/grpc/chatgrpc"
+	"github.com/Gilf4/grpcChat/chat/internal/grpc/interceptors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -19,9 +20,16 @@ type App struct {
 func New(
 	log *slog.Logger,
 	port int,
+	jwtSecret string,
+	hub *chatgrpc.Hub,
+	chatService chatgrpc.Chat,
+	presenceService chatgrpc.Presence,
 ) *App {
-	gRPCServer := grpc.NewServer()
-	chatgrpc.Register(gRPCServer)
+	gRPCServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptors.UnaryAuth(jwtSecret)),
+		grpc.ChainStreamInterceptor(interceptors.StreamAuth(jwtSecret)),
+	)
+	chatgrpc.Register(gRPCServer, hub, chatService, presenceService)
 	reflection.Register(gRPCServer)
 
 	return &App{
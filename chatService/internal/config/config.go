@@ -10,9 +10,13 @@ import (
 )
 
 type Config struct {
-	Env  string     `yaml:"env" env-default:"local"`
-	GRPC GrpcConfig `yaml:"grpc"`
-	DB   DBConfig   `yaml:"db"`
+	Env         string            `yaml:"env" env-default:"local"`
+	GRPC        GrpcConfig        `yaml:"grpc"`
+	DB          DBConfig          `yaml:"db"`
+	JWTSecret   string            `yaml:"jwt_secret" env-required:"true"`
+	Attachments AttachmentsConfig `yaml:"attachments"`
+	Retention   RetentionConfig   `yaml:"retention"`
+	Import      ImportConfig      `yaml:"import"`
 }
 
 type GrpcConfig struct {
@@ -28,6 +32,23 @@ type DBConfig struct {
 	DBName   string `yaml:"name"`
 }
 
+type AttachmentsConfig struct {
+	Dir          string   `yaml:"dir" env-default:"./data/attachments"`
+	MaxSize      int64    `yaml:"max_size" env-default:"26214400"`
+	AllowedTypes []string `yaml:"allowed_types" env-default:"image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain"`
+}
+
+// RetentionConfig controls the janitor that removes expired messages.
+type RetentionConfig struct {
+	Interval  time.Duration `yaml:"interval" env-default:"15s"`
+	BatchSize int           `yaml:"batch_size" env-default:"500"`
+}
+
+type ImportConfig struct {
+	// AllowedUsers may call ImportChat. Imports are disabled when empty.
+	AllowedUsers []int64 `yaml:"allowed_users"`
+}
+
 func MustLoad() *Config {
 	var cfg Config
 
@@ -64,5 +85,8 @@ func (c Config) LogValue() slog.Value {
 		slog.String("env", c.Env),
 		slog.Any("grpc", c.GRPC),
 		slog.Any("db", c.DB),
+		slog.Any("attachments", c.Attachments),
+		slog.Any("retention", c.Retention),
+		slog.Any("import", c.Import),
 	)
 }
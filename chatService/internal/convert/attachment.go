@@ -0,0 +1,43 @@
+package convert
+
+import (
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+)
+
+func AttachmentToProto(att models.Attachment) *chatv1.Attachment {
+	res := &chatv1.Attachment{
+		Id:          att.ID,
+		Filename:    att.Filename,
+		ContentType: att.ContentType,
+		Size:        att.Size,
+		Width:       int32(att.Width),
+		Height:      int32(att.Height),
+	}
+	for _, t := range att.Thumbnails {
+		res.Thumbnails = append(res.Thumbnails, ThumbnailToProto(t))
+	}
+	return res
+}
+
+func ThumbnailToProto(thumb models.Thumbnail) *chatv1.Thumbnail {
+	return &chatv1.Thumbnail{
+		Id:          thumb.ID,
+		Width:       int32(thumb.Width),
+		Height:      int32(thumb.Height),
+		ContentType: thumb.ContentType,
+		Size:        thumb.Size,
+	}
+}
+
+func ToProtoAttachments(atts []models.Attachment) []*chatv1.Attachment {
+	if len(atts) == 0 {
+		return nil
+	}
+
+	res := make([]*chatv1.Attachment, 0, len(atts))
+	for _, a := range atts {
+		res = append(res, AttachmentToProto(a))
+	}
+	return res
+}
@@ -6,16 +6,28 @@ import (
 )
 
 func ChatToProto(chat models.Chat) *chatv1.Chat {
-	return &chatv1.Chat{
-		Id:   chat.ID,
-		Name: chat.Name,
+	res := &chatv1.Chat{
+		Id:          chat.ID,
+		Name:        chat.Name,
+		Kind:        ChatKindToProto(chat.Kind),
+		Public:      chat.Public,
+		PeerUserId:  chat.PeerID,
+		UnreadCount: chat.UnreadCount,
+		Retention:   RetentionToProto(chat.Retention),
 	}
+	if chat.LastMessage != nil {
+		res.LastMessage = MessageToProto(*chat.LastMessage)
+	}
+	return res
 }
 
 func ProtoToChat(proto *chatv1.Chat) *models.Chat {
 	return &models.Chat{
-		ID:   proto.Id,
-		Name: proto.Name,
+		ID:     proto.Id,
+		Name:   proto.Name,
+		Kind:   ProtoToChatKind(proto.Kind),
+		Public: proto.Public,
+		PeerID: proto.PeerUserId,
 	}
 }
 
@@ -26,3 +38,25 @@ func ToProtoChatList(chats []models.Chat) []*chatv1.Chat {
 	}
 	return res
 }
+
+func ChatKindToProto(kind models.ChatKind) chatv1.ChatKind {
+	switch kind {
+	case models.ChatKindGroup:
+		return chatv1.ChatKind_CHAT_KIND_GROUP
+	case models.ChatKindDirect:
+		return chatv1.ChatKind_CHAT_KIND_DIRECT
+	default:
+		return chatv1.ChatKind_CHAT_KIND_UNSPECIFIED
+	}
+}
+
+func ProtoToChatKind(kind chatv1.ChatKind) models.ChatKind {
+	switch kind {
+	case chatv1.ChatKind_CHAT_KIND_GROUP:
+		return models.ChatKindGroup
+	case chatv1.ChatKind_CHAT_KIND_DIRECT:
+		return models.ChatKindDirect
+	default:
+		return ""
+	}
+}
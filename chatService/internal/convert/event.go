@@ -0,0 +1,132 @@
+package convert
+
+import (
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func MessageCreatedEvent(msg models.Message) *chatv1.ChatEvent {
+	return &chatv1.ChatEvent{
+		ChatId: msg.ChatID,
+		Event:  &chatv1.ChatEvent_MessageCreated{MessageCreated: MessageToProto(msg)},
+	}
+}
+
+func MessageEditedEvent(msg models.Message) *chatv1.ChatEvent {
+	return &chatv1.ChatEvent{
+		ChatId: msg.ChatID,
+		Event:  &chatv1.ChatEvent_MessageEdited{MessageEdited: MessageToProto(msg)},
+	}
+}
+
+func MessageDeletedEvent(msg models.Message) *chatv1.ChatEvent {
+	deleted := &chatv1.MessageDeleted{MessageId: msg.ID}
+	if msg.DeletedAt != nil {
+		deleted.DeletedAt = timestamppb.New(*msg.DeletedAt)
+	}
+
+	return &chatv1.ChatEvent{
+		ChatId: msg.ChatID,
+		Event:  &chatv1.ChatEvent_MessageDeleted{MessageDeleted: deleted},
+	}
+}
+
+func ThreadUpdatedEvent(chatID int64, thread models.Thread) *chatv1.ChatEvent {
+	updated := &chatv1.ThreadUpdated{
+		RootMessageId: thread.RootID,
+		ReplyCount:    thread.ReplyCount,
+	}
+	if !thread.LastReplyAt.IsZero() {
+		updated.LastReplyAt = timestamppb.New(thread.LastReplyAt)
+	}
+
+	return &chatv1.ChatEvent{
+		ChatId: chatID,
+		Event:  &chatv1.ChatEvent_ThreadUpdated{ThreadUpdated: updated},
+	}
+}
+
+func ReactionsChangedEvent(msg models.Message) *chatv1.ChatEvent {
+	return &chatv1.ChatEvent{
+		ChatId: msg.ChatID,
+		Event: &chatv1.ChatEvent_ReactionsChanged{ReactionsChanged: &chatv1.ReactionsChanged{
+			MessageId: msg.ID,
+			Reactions: ToProtoReactionCounts(msg.Reactions),
+		}},
+	}
+}
+
+func ReadReceiptEvent(receipt models.ReadReceipt) *chatv1.ChatEvent {
+	return &chatv1.ChatEvent{
+		ChatId: receipt.ChatID,
+		Event: &chatv1.ChatEvent_ReadReceipt{ReadReceipt: &chatv1.ReadReceipt{
+			UserId:    receipt.UserID,
+			MessageId: receipt.MessageID,
+			Seq:       receipt.Seq,
+			ReadAt:    timestamppb.New(receipt.ReadAt),
+		}},
+	}
+}
+
+func TypingEvent(chatID, userID int64, typing bool, expiresAt time.Time) *chatv1.ChatEvent {
+	event := &chatv1.Typing{UserId: userID, Typing: typing}
+	if typing {
+		event.ExpiresAt = timestamppb.New(expiresAt)
+	}
+
+	return &chatv1.ChatEvent{
+		ChatId: chatID,
+		Event:  &chatv1.ChatEvent_Typing{Typing: event},
+	}
+}
+
+func MemberJoinedEvent(chatID, userID int64) *chatv1.ChatEvent {
+	return &chatv1.ChatEvent{
+		ChatId: chatID,
+		Event:  &chatv1.ChatEvent_MemberJoined{MemberJoined: &chatv1.MemberJoined{UserId: userID}},
+	}
+}
+
+func MemberLeftEvent(chatID, userID int64) *chatv1.ChatEvent {
+	return &chatv1.ChatEvent{
+		ChatId: chatID,
+		Event:  &chatv1.ChatEvent_MemberLeft{MemberLeft: &chatv1.MemberLeft{UserId: userID}},
+	}
+}
+
+func MentionEvent(msg models.Message) *chatv1.ChatEvent {
+	return &chatv1.ChatEvent{
+		ChatId: msg.ChatID,
+		Event:  &chatv1.ChatEvent_Mention{Mention: &chatv1.Mention{Message: MessageToProto(msg)}},
+	}
+}
+
+func MessagePinnedEvent(pin models.Pin) *chatv1.ChatEvent {
+	return &chatv1.ChatEvent{
+		ChatId: pin.Message.ChatID,
+		Event:  &chatv1.ChatEvent_MessagePinned{MessagePinned: PinToProto(pin)},
+	}
+}
+
+func MessageUnpinnedEvent(msg models.Message, userID int64) *chatv1.ChatEvent {
+	return &chatv1.ChatEvent{
+		ChatId: msg.ChatID,
+		Event: &chatv1.ChatEvent_MessageUnpinned{MessageUnpinned: &chatv1.MessageUnpinned{
+			MessageId:  msg.ID,
+			UnpinnedBy: userID,
+		}},
+	}
+}
+
+func RetentionChangedEvent(chatID int64, retention models.Retention, userID int64) *chatv1.ChatEvent {
+	return &chatv1.ChatEvent{
+		ChatId: chatID,
+		Event: &chatv1.ChatEvent_RetentionChanged{RetentionChanged: &chatv1.RetentionChanged{
+			Retention: RetentionToProto(retention),
+			ChangedBy: userID,
+		}},
+	}
+}
@@ -0,0 +1,55 @@
+package convert
+
+import (
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func MemberToProto(member models.Member) *chatv1.Member {
+	return &chatv1.Member{
+		UserId:   member.UserID,
+		JoinedAt: timestamppb.New(member.JoinedAt),
+		Role:     RoleToProto(member.Role),
+		Handle:   member.Handle,
+	}
+}
+
+func ToProtoMemberList(members []models.Member) []*chatv1.Member {
+	res := make([]*chatv1.Member, 0, len(members))
+	for _, m := range members {
+		res = append(res, MemberToProto(m))
+	}
+	return res
+}
+
+func RoleToProto(role models.Role) chatv1.Role {
+	switch role {
+	case models.RoleOwner:
+		return chatv1.Role_ROLE_OWNER
+	case models.RoleAdmin:
+		return chatv1.Role_ROLE_ADMIN
+	case models.RoleMember:
+		return chatv1.Role_ROLE_MEMBER
+	case models.RoleReadOnly:
+		return chatv1.Role_ROLE_READ_ONLY
+	default:
+		return chatv1.Role_ROLE_UNSPECIFIED
+	}
+}
+
+// ProtoToRole returns an empty role for ROLE_UNSPECIFIED and unknown values.
+func ProtoToRole(role chatv1.Role) models.Role {
+	switch role {
+	case chatv1.Role_ROLE_OWNER:
+		return models.RoleOwner
+	case chatv1.Role_ROLE_ADMIN:
+		return models.RoleAdmin
+	case chatv1.Role_ROLE_MEMBER:
+		return models.RoleMember
+	case chatv1.Role_ROLE_READ_ONLY:
+		return models.RoleReadOnly
+	default:
+		return ""
+	}
+}
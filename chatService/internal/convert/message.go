@@ -0,0 +1,66 @@
+package convert
+
+import (
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func MessageToProto(msg models.Message) *chatv1.Message {
+	res := &chatv1.Message{
+		Id:          msg.ID,
+		ChatId:      msg.ChatID,
+		SenderId:    msg.SenderID,
+		Text:        msg.Text,
+		CreatedAt:   timestamppb.New(msg.CreatedAt),
+		Seq:         msg.Seq,
+		Deleted:     msg.DeletedAt != nil,
+		Reactions:   ToProtoReactionCounts(msg.Reactions),
+		Attachments: ToProtoAttachments(msg.Attachments),
+
+		ReplyToMessageId: msg.ReplyToID,
+		ReplyCount:       msg.ReplyCount,
+		MentionedUserIds: msg.MentionedUserIDs,
+	}
+	if msg.EditedAt != nil {
+		res.EditedAt = timestamppb.New(*msg.EditedAt)
+	}
+	if msg.LastReplyAt != nil {
+		res.LastReplyAt = timestamppb.New(*msg.LastReplyAt)
+	}
+	return res
+}
+
+func ToProtoReactionCounts(counts []models.ReactionCount) []*chatv1.ReactionCount {
+	res := make([]*chatv1.ReactionCount, 0, len(counts))
+	for _, c := range counts {
+		res = append(res, &chatv1.ReactionCount{Emoji: c.Emoji, Count: c.Count})
+	}
+	return res
+}
+
+func ToProtoMessageList(msgs []models.Message) []*chatv1.Message {
+	res := make([]*chatv1.Message, 0, len(msgs))
+	for _, m := range msgs {
+		res = append(res, MessageToProto(m))
+	}
+	return res
+}
+
+func ProtoToDirection(direction chatv1.Direction) models.Direction {
+	if direction == chatv1.Direction_DIRECTION_NEWER {
+		return models.DirectionNewer
+	}
+	return models.DirectionOlder
+}
+
+func ToProtoSearchResults(results []models.SearchResult) []*chatv1.SearchResult {
+	res := make([]*chatv1.SearchResult, 0, len(results))
+	for _, r := range results {
+		res = append(res, &chatv1.SearchResult{
+			Message: MessageToProto(r.Message),
+			Snippet: r.Snippet,
+		})
+	}
+	return res
+}
@@ -0,0 +1,28 @@
+package convert
+
+import (
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func NotificationToProto(n models.Notification) *chatv1.Notification {
+	res := &chatv1.Notification{
+		Id:        n.ID,
+		ChatId:    n.Message.ChatID,
+		Message:   MessageToProto(n.Message),
+		CreatedAt: timestamppb.New(n.CreatedAt),
+	}
+	if n.ReadAt != nil {
+		res.ReadAt = timestamppb.New(*n.ReadAt)
+	}
+	return res
+}
+
+func ToProtoNotificationList(notifications []models.Notification) []*chatv1.Notification {
+	res := make([]*chatv1.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		res = append(res, NotificationToProto(n))
+	}
+	return res
+}
@@ -0,0 +1,23 @@
+package convert
+
+import (
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func PinToProto(pin models.Pin) *chatv1.Pin {
+	return &chatv1.Pin{
+		Message:  MessageToProto(pin.Message),
+		PinnedBy: pin.PinnedBy,
+		PinnedAt: timestamppb.New(pin.PinnedAt),
+	}
+}
+
+func ToProtoPinList(pins []models.Pin) []*chatv1.Pin {
+	res := make([]*chatv1.Pin, 0, len(pins))
+	for _, pin := range pins {
+		res = append(res, PinToProto(pin))
+	}
+	return res
+}
@@ -0,0 +1,39 @@
+package convert
+
+import (
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func PresenceToProto(presence models.Presence) *chatv1.Presence {
+	res := &chatv1.Presence{
+		UserId: presence.UserID,
+		Status: PresenceStatusToProto(presence.Status),
+	}
+	if presence.LastSeenAt != nil {
+		res.LastSeenAt = timestamppb.New(*presence.LastSeenAt)
+	}
+	return res
+}
+
+func ToProtoPresenceList(presences []models.Presence) []*chatv1.Presence {
+	res := make([]*chatv1.Presence, 0, len(presences))
+	for _, p := range presences {
+		res = append(res, PresenceToProto(p))
+	}
+	return res
+}
+
+func PresenceStatusToProto(status models.PresenceStatus) chatv1.PresenceStatus {
+	switch status {
+	case models.PresenceOnline:
+		return chatv1.PresenceStatus_PRESENCE_STATUS_ONLINE
+	case models.PresenceAway:
+		return chatv1.PresenceStatus_PRESENCE_STATUS_AWAY
+	case models.PresenceOffline:
+		return chatv1.PresenceStatus_PRESENCE_STATUS_OFFLINE
+	default:
+		return chatv1.PresenceStatus_PRESENCE_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,46 @@
+package convert
+
+import (
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+)
+
+func RetentionToProto(retention models.Retention) *chatv1.Retention {
+	switch retention.Mode {
+	case models.RetentionDays:
+		return &chatv1.Retention{
+			Mode: chatv1.RetentionMode_RETENTION_MODE_DAYS,
+			Days: int32(retention.TTL / (24 * time.Hour)),
+		}
+	case models.RetentionDisappearing:
+		return &chatv1.Retention{
+			Mode:       chatv1.RetentionMode_RETENTION_MODE_DISAPPEARING,
+			TtlSeconds: int64(retention.TTL / time.Second),
+		}
+	default:
+		return &chatv1.Retention{Mode: chatv1.RetentionMode_RETENTION_MODE_FOREVER}
+	}
+}
+
+// ProtoToRetention maps a retention from the API. Fields that do not belong to
+// the mode are ignored; an unknown mode yields an empty Mode.
+func ProtoToRetention(retention *chatv1.Retention) models.Retention {
+	switch retention.GetMode() {
+	case chatv1.RetentionMode_RETENTION_MODE_FOREVER:
+		return models.Retention{Mode: models.RetentionForever}
+	case chatv1.RetentionMode_RETENTION_MODE_DAYS:
+		return models.Retention{
+			Mode: models.RetentionDays,
+			TTL:  time.Duration(retention.GetDays()) * 24 * time.Hour,
+		}
+	case chatv1.RetentionMode_RETENTION_MODE_DISAPPEARING:
+		return models.Retention{
+			Mode: models.RetentionDisappearing,
+			TTL:  time.Duration(retention.GetTtlSeconds()) * time.Second,
+		}
+	default:
+		return models.Retention{}
+	}
+}
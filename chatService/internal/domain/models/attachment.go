@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type Attachment struct {
+	ID     int64
+	ChatID int64
+	// MessageID is zero until the attachment is sent with a message.
+	MessageID   int64
+	UploaderID  int64
+	Filename    string
+	ContentType string
+	Size        int64
+	// StorageKey locates the content in the blob store.
+	StorageKey string
+	CreatedAt  time.Time
+	// Width and Height are set for images only.
+	Width      int
+	Height     int
+	Thumbnails []Thumbnail
+}
+
+// Thumbnail is a downscaled preview of an image attachment.
+type Thumbnail struct {
+	ID           int64
+	AttachmentID int64
+	Width        int
+	Height       int
+	ContentType  string
+	Size         int64
+	StorageKey   string
+}
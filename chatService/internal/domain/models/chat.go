@@ -1,6 +1,24 @@
 package models
 
+type ChatKind string
+
+const (
+	ChatKindGroup  ChatKind = "group"
+	ChatKindDirect ChatKind = "direct"
+)
+
 type Chat struct {
 	ID   int64
 	Name string
+	Kind ChatKind
+	// Public group chats can be joined by anyone; members of other chats
+	// are only ever added by an admin.
+	Public bool
+	// PeerID is the other participant of a direct chat, as seen by the
+	// user the chat was loaded for. Zero for group chats.
+	PeerID    int64
+	Retention Retention
+	// UnreadCount and LastMessage are only populated in chat lists.
+	UnreadCount int64
+	LastMessage *Message
 }
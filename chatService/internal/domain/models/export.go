@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// User is the account information a chat export needs to identify people
+// across environments.
+type User struct {
+	ID    int64
+	Email string
+	Name  string
+}
+
+// Reaction is a single user's reaction to a message.
+type Reaction struct {
+	MessageID int64
+	UserID    int64
+	Emoji     string
+	CreatedAt time.Time
+}
+
+// ExportSink receives a chat read from one consistent snapshot, in this
+// order: the chat, the users it refers to, its members, its messages in
+// batches by ascending sequence number, and its pins.
+type ExportSink interface {
+	Chat(chat Chat, createdAt time.Time) error
+	Users(users []User) error
+	Members(members []Member) error
+	// Messages carries the attachments of msgs in place and their reactions
+	// keyed by message id.
+	Messages(msgs []Message, reactions map[int64][]Reaction) error
+	// Pins only carry the id of each pinned message.
+	Pins(pins []Pin) error
+}
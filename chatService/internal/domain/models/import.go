@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ChatImport identifies a chat being imported from another environment. The
+// source chat id and creation time together recognise a re-run of the same
+// import.
+type ChatImport struct {
+	SourceChatID    int64
+	SourceCreatedAt time.Time
+	ImportedBy      int64
+	// Chat holds the name, kind and retention of the chat to create.
+	Chat Chat
+	// DirectUserIDs are the two participants of a direct chat.
+	DirectUserIDs []int64
+}
+
+// ImportedMessage is a message of an import with its sender, mentions,
+// reactions and attachments already mapped to this environment.
+type ImportedMessage struct {
+	SourceID int64
+	// SourceReplyToID is the thread root in the source environment, zero
+	// for top-level messages.
+	SourceReplyToID int64
+	Message         Message
+	Reactions       []Reaction
+}
+
+// ImportedPin refers to its message by source id.
+type ImportedPin struct {
+	SourceMessageID int64
+	PinnedBy        int64
+	PinnedAt        time.Time
+}
+
+type ImportResult struct {
+	Chat      Chat
+	MemberIDs []int64
+	// AlreadyImported is set when an earlier run already completed the
+	// import; nothing was changed then.
+	AlreadyImported    bool
+	Imported           int
+	Skipped            int
+	MissingAttachments int
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+type Role string
+
+const (
+	RoleOwner    Role = "owner"
+	RoleAdmin    Role = "admin"
+	RoleMember   Role = "member"
+	RoleReadOnly Role = "read_only"
+)
+
+// Outranks reports whether r sits strictly above other in the role hierarchy.
+func (r Role) Outranks(other Role) bool {
+	return r.rank() > other.rank()
+}
+
+func (r Role) rank() int {
+	switch r {
+	case RoleOwner:
+		return 4
+	case RoleAdmin:
+		return 3
+	case RoleMember:
+		return 2
+	case RoleReadOnly:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type Member struct {
+	ChatID   int64
+	UserID   int64
+	Role     Role
+	JoinedAt time.Time
+	// Handle is only filled in by member listings.
+	Handle string
+}
+
+// ReadReceipt is a member's read cursor within a chat.
+type ReadReceipt struct {
+	ChatID    int64
+	UserID    int64
+	MessageID int64
+	Seq       int64
+	ReadAt    time.Time
+}
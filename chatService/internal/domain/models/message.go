@@ -1,8 +1,43 @@
 package models
 
+import "time"
+
 type Message struct {
-	ID       string
-	ChatID   string
-	SenderID string
-	Text     string
+	ID        int64
+	ChatID    int64
+	SenderID  int64
+	Text      string
+	Seq       int64
+	CreatedAt time.Time
+	EditedAt  *time.Time
+	// DeletedAt is set on tombstones; their text is cleared.
+	DeletedAt *time.Time
+	// ReplyToID is the root message of the thread this message belongs to,
+	// zero for top-level messages.
+	ReplyToID   int64
+	ReplyCount  int64
+	LastReplyAt *time.Time
+	// MentionedUserIDs are the members mentioned in the current text.
+	MentionedUserIDs []int64
+	// Reactions is only populated by the service when loading history.
+	Reactions   []ReactionCount
+	Attachments []Attachment
+	// Thread holds the updated counters of the thread root. It is only set
+	// on replies returned when they are sent or deleted.
+	Thread *Thread
+}
+
+// Thread is the reply counters of a thread root. LastReplyAt is zero once
+// the thread has no replies left.
+type Thread struct {
+	RootID      int64
+	ReplyCount  int64
+	LastReplyAt time.Time
 }
+
+type Direction int
+
+const (
+	DirectionOlder Direction = iota
+	DirectionNewer
+)
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Notification is an entry of a user's inbox; currently always a mention.
+type Notification struct {
+	ID        int64
+	UserID    int64
+	Message   Message
+	CreatedAt time.Time
+	// ReadAt is nil until the user marks the notification read.
+	ReadAt *time.Time
+}
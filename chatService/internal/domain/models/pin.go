@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+type Pin struct {
+	Message  Message
+	PinnedBy int64
+	PinnedAt time.Time
+}
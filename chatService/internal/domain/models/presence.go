@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type PresenceStatus string
+
+const (
+	PresenceOnline  PresenceStatus = "online"
+	PresenceAway    PresenceStatus = "away"
+	PresenceOffline PresenceStatus = "offline"
+)
+
+type Presence struct {
+	UserID int64
+	Status PresenceStatus
+	// LastSeenAt is when the user's last stream closed. It is nil for users
+	// who have never connected.
+	LastSeenAt *time.Time
+}
@@ -0,0 +1,7 @@
+package models
+
+// ReactionCount is the number of users who reacted to a message with an emoji.
+type ReactionCount struct {
+	Emoji string
+	Count int64
+}
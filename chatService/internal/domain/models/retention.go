@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+type RetentionMode string
+
+const (
+	RetentionForever RetentionMode = "forever"
+	// RetentionDays keeps messages for a whole number of days.
+	RetentionDays RetentionMode = "days"
+	// RetentionDisappearing removes messages a short, fixed time after they
+	// were sent.
+	RetentionDisappearing RetentionMode = "disappearing"
+)
+
+// Retention is how long the messages of a chat are kept. TTL is zero when
+// messages are kept forever.
+type Retention struct {
+	Mode RetentionMode
+	TTL  time.Duration
+}
+
+// Expired is what one batch of the retention janitor removed.
+type Expired struct {
+	// Messages were purged or tombstoned. They only carry ID, ChatID and
+	// DeletedAt.
+	Messages []Message
+	// Roots are the thread roots that lost purged replies, with ID, ChatID
+	// and their new reply counters.
+	Roots []Message
+	// Unpinned are the expired messages that were pinned, with ID and ChatID.
+	Unpinned []Message
+	// BlobKeys are the blobs of the dropped attachments and their thumbnails.
+	BlobKeys []string
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// SearchFilter narrows a message search. Zero values leave a filter unset.
+type SearchFilter struct {
+	Query    string
+	ChatIDs  []int64
+	SenderID int64
+	// From is inclusive, To is exclusive.
+	From *time.Time
+	To   *time.Time
+}
+
+type SearchResult struct {
+	Message Message
+	// Snippet is an excerpt of the text with the matches wrapped in
+	// <mark></mark>.
+	Snippet string
+}
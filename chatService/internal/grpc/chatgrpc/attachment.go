@@ -0,0 +1,174 @@
+package chatgrpc
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxFilenameLength     = 255
+	maxMessageAttachments = 10
+	downloadChunkSize     = 64 << 10
+)
+
+func (s *serverApi) UploadAttachment(stream chatv1.ChatService_UploadAttachmentServer) error {
+	userID, err := callerID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "info is required")
+		}
+		return err
+	}
+
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry info")
+	}
+
+	filename := strings.TrimSpace(info.GetFilename())
+	if filename == "" {
+		return status.Error(codes.InvalidArgument, "filename is required")
+	}
+	if len(filename) > maxFilenameLength || strings.ContainsAny(filename, `/\`) {
+		return status.Error(codes.InvalidArgument, "invalid filename")
+	}
+
+	att, err := s.chat.UploadAttachment(stream.Context(), userID, info.GetChatId(), filename, &uploadReader{stream: stream})
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return status.Error(codes.PermissionDenied, "permission denied")
+		}
+		if errors.Is(err, services.ErrAttachmentTooLarge) {
+			return status.Error(codes.InvalidArgument, "attachment is too large")
+		}
+		if errors.Is(err, services.ErrAttachmentType) {
+			return status.Error(codes.InvalidArgument, "attachment type is not allowed")
+		}
+		if errors.Is(err, services.ErrAttachmentEmpty) {
+			return status.Error(codes.InvalidArgument, "attachment is empty")
+		}
+		// Errors of the upload stream itself are passed through as they are.
+		var streamErr interface{ GRPCStatus() *status.Status }
+		if errors.As(err, &streamErr) {
+			return streamErr.GRPCStatus().Err()
+		}
+
+		return status.Error(codes.Internal, "failed to upload attachment")
+	}
+
+	return stream.SendAndClose(convert.AttachmentToProto(att))
+}
+
+func (s *serverApi) DownloadAttachment(
+	req *chatv1.DownloadAttachmentRequest,
+	stream chatv1.ChatService_DownloadAttachmentServer,
+) error {
+	userID, err := callerID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	att, content, err := s.chat.DownloadAttachment(stream.Context(), userID, req.GetAttachmentId(), req.GetThumbnailId())
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return status.Error(codes.PermissionDenied, "permission denied")
+		}
+		if errors.Is(err, postgres.ErrAttachmentNotFound) || errors.Is(err, postgres.ErrMessageNotFound) {
+			return status.Error(codes.NotFound, "attachment not found")
+		}
+
+		return status.Error(codes.Internal, "failed to download attachment")
+	}
+	defer content.Close()
+
+	// A thumbnail is described by its own metadata: its type and size differ
+	// from the original's.
+	first := &chatv1.DownloadAttachmentResponse{
+		Data: &chatv1.DownloadAttachmentResponse_Attachment{Attachment: convert.AttachmentToProto(att)},
+	}
+	if thumbnailID := req.GetThumbnailId(); thumbnailID != 0 {
+		i := slices.IndexFunc(att.Thumbnails, func(t models.Thumbnail) bool { return t.ID == thumbnailID })
+		if i < 0 {
+			return status.Error(codes.NotFound, "attachment not found")
+		}
+		first.Data = &chatv1.DownloadAttachmentResponse_Thumbnail{Thumbnail: convert.ThumbnailToProto(att.Thumbnails[i])}
+	}
+
+	if err := stream.Send(first); err != nil {
+		return err
+	}
+
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			err := stream.Send(&chatv1.DownloadAttachmentResponse{
+				Data: &chatv1.DownloadAttachmentResponse_Chunk{Chunk: buf[:n]},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, "failed to read attachment")
+		}
+	}
+}
+
+// uploadReader presents the chunks of an upload stream as an io.Reader.
+type uploadReader struct {
+	stream chatv1.ChatService_UploadAttachmentServer
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if req.GetInfo() != nil {
+			return 0, status.Error(codes.InvalidArgument, "info must only be sent first")
+		}
+		r.buf = req.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func validateAttachmentIDs(ids []int64) error {
+	if len(ids) > maxMessageAttachments {
+		return status.Errorf(codes.InvalidArgument, "at most %d attachments per message", maxMessageAttachments)
+	}
+	for i, id := range ids {
+		if id <= 0 {
+			return status.Error(codes.InvalidArgument, "invalid attachment id")
+		}
+		for _, prev := range ids[:i] {
+			if prev == id {
+				return status.Error(codes.InvalidArgument, "duplicate attachment id")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package chatgrpc
+
+import (
+	"context"
+
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *serverApi) StreamEvents(_ *emptypb.Empty, stream chatv1.ChatService_StreamEventsServer) error {
+	userID, err := callerID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	s.presence.Connect(userID)
+	defer s.presence.Disconnect(context.WithoutCancel(stream.Context()), userID)
+
+	// Subscribe before listing the chats so that a join or leave in between
+	// is not missed: the hub applies it on top of the listing.
+	sub := s.hub.SubscribeUser(userID)
+	defer s.hub.UnsubscribeUser(userID, sub)
+
+	chatIDs, err := s.chat.ChatIDs(stream.Context(), userID)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to list chats")
+	}
+	s.hub.InitUser(sub, chatIDs)
+
+	return s.forward(stream.Context(), sub, userID, 0, stream.Send)
+}
@@ -0,0 +1,53 @@
+package chatgrpc
+
+import (
+	"bufio"
+	"errors"
+
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is how much of the export is buffered per response.
+const exportChunkSize = 64 << 10
+
+func (s *serverApi) ExportChat(req *chatv1.ExportChatRequest, stream chatv1.ChatService_ExportChatServer) error {
+	userID, err := callerID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriterSize(exportStream{stream: stream}, exportChunkSize)
+
+	if err := s.chat.ExportChat(stream.Context(), userID, req.GetChatId(), w); err != nil {
+		if errors.Is(err, postgres.ErrChatNotFound) {
+			return status.Error(codes.NotFound, "chat not found")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return status.Error(codes.Internal, "failed to export chat")
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// exportStream sends everything written to it as export chunks.
+type exportStream struct {
+	stream chatv1.ChatService_ExportChatServer
+}
+
+func (w exportStream) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&chatv1.ExportChatResponse{Chunk: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,300 @@
+package chatgrpc
+
+import (
+	"sync"
+
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// subscriptionBufferSize is how many events a subscriber may fall behind
+// before the hub drops it.
+const subscriptionBufferSize = 64
+
+// errSubscriberLagging ends a stream whose subscription was dropped because
+// it stopped keeping up. The client resumes by reconnecting with the id of
+// the last message it received.
+var errSubscriberLagging = status.Error(codes.ResourceExhausted, "too many undelivered events, reconnect with last_seen_message_id")
+
+// errLeftChat ends a chat subscription whose user is no longer a member.
+var errLeftChat = status.Error(codes.PermissionDenied, "no longer a member of the chat")
+
+// Subscription is a feed of hub events on behalf of a user. A chat
+// subscription follows one chat until the user leaves it; a user subscription
+// follows every chat in chats, which is kept up to date through Join and
+// Leave; a direct subscription only gets the events sent to its user. The hub
+// closes ch when it drops the subscription and sets err to the reason first.
+type Subscription struct {
+	userID int64
+	ch     chan *chatv1.ChatEvent
+	err    error
+	// skipDirect is set on chat subscriptions whose stream holds a direct
+	// subscription, which the events sent to the user go through instead.
+	skipDirect bool
+	chats      map[int64]struct{}
+	// left records the chats a user subscription left before its initial
+	// chats were set; nil once they are.
+	left map[int64]struct{}
+}
+
+func newSubscription() *Subscription {
+	return &Subscription{ch: make(chan *chatv1.ChatEvent, subscriptionBufferSize)}
+}
+
+// Events returns the channel the subscription's events are delivered on.
+func (sub *Subscription) Events() <-chan *chatv1.ChatEvent {
+	return sub.ch
+}
+
+// Err returns why the hub dropped the subscription. It is only meaningful
+// once the events channel is closed, and nil after an unsubscribe.
+func (sub *Subscription) Err() error {
+	return sub.err
+}
+
+type Hub struct {
+	mu      sync.Mutex
+	streams map[int64]map[*Subscription]struct{}
+
+	users     map[int64]map[*Subscription]struct{}
+	chatUsers map[int64]map[*Subscription]struct{}
+
+	direct map[int64]map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		streams:   make(map[int64]map[*Subscription]struct{}),
+		users:     make(map[int64]map[*Subscription]struct{}),
+		chatUsers: make(map[int64]map[*Subscription]struct{}),
+		direct:    make(map[int64]map[*Subscription]struct{}),
+	}
+}
+
+func (h *Hub) Subscribe(userID, chatID int64) *Subscription {
+	sub := newSubscription()
+	sub.userID = userID
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	addSub(h.streams, chatID, sub)
+
+	return sub
+}
+
+// SubscribeSession is Subscribe for a stream that also holds a direct
+// subscription: events sent to the user reach the stream through that one
+// only.
+func (h *Hub) SubscribeSession(userID, chatID int64) *Subscription {
+	sub := h.Subscribe(userID, chatID)
+	sub.skipDirect = true
+	return sub
+}
+
+func (h *Hub) Unsubscribe(chatID int64, sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.streams[chatID][sub]; ok {
+		h.dropChatSub(chatID, sub, nil)
+	}
+}
+
+// SubscribeUser opens a stream of the events of the user's chats. Chats the
+// user joins or leaves from now on are added or dropped; the chats the user
+// already belongs to are set with InitUser.
+func (h *Hub) SubscribeUser(userID int64) *Subscription {
+	sub := newSubscription()
+	sub.userID = userID
+	sub.chats = make(map[int64]struct{})
+	sub.left = make(map[int64]struct{})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	addSub(h.users, userID, sub)
+
+	return sub
+}
+
+// InitUser adds the chats a user subscription starts out with. chatIDs must
+// be listed after SubscribeUser returned; chats the user left in between are
+// skipped, since the listing may predate the leave.
+func (h *Hub) InitUser(sub *Subscription, chatIDs []int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.users[sub.userID][sub]; !ok {
+		return
+	}
+
+	for _, chatID := range chatIDs {
+		if _, ok := sub.left[chatID]; ok {
+			continue
+		}
+		sub.chats[chatID] = struct{}{}
+		addSub(h.chatUsers, chatID, sub)
+	}
+	sub.left = nil
+}
+
+func (h *Hub) UnsubscribeUser(userID int64, sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.users[userID][sub]; ok {
+		h.dropUserSub(sub, nil)
+	}
+}
+
+// SubscribeDirect opens a stream of the events sent to the user in any chat.
+func (h *Hub) SubscribeDirect(userID int64) *Subscription {
+	sub := newSubscription()
+	sub.userID = userID
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	addSub(h.direct, userID, sub)
+
+	return sub
+}
+
+func (h *Hub) UnsubscribeDirect(userID int64, sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.direct[userID][sub]; ok {
+		h.dropDirectSub(sub, nil)
+	}
+}
+
+// Join adds the chat to every user-level stream of the user.
+func (h *Hub) Join(userID, chatID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.users[userID] {
+		delete(sub.left, chatID)
+		sub.chats[chatID] = struct{}{}
+		addSub(h.chatUsers, chatID, sub)
+	}
+}
+
+// Leave drops the chat from every user-level stream of the user and closes
+// the user's subscriptions to the chat.
+func (h *Hub) Leave(userID, chatID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.streams[chatID] {
+		if sub.userID == userID {
+			h.dropChatSub(chatID, sub, errLeftChat)
+		}
+	}
+
+	for sub := range h.users[userID] {
+		if sub.left != nil {
+			sub.left[chatID] = struct{}{}
+		}
+		delete(sub.chats, chatID)
+		removeSub(h.chatUsers, chatID, sub)
+	}
+}
+
+// Broadcast delivers an event to every subscription of its chat. A
+// subscription with a full buffer is dropped rather than skipped, so a
+// subscriber never misses an event without noticing.
+func (h *Hub) Broadcast(event *chatv1.ChatEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	chatID := event.ChatId
+	for sub := range h.streams[chatID] {
+		if !deliver(sub, event) {
+			h.dropChatSub(chatID, sub, errSubscriberLagging)
+		}
+	}
+
+	for sub := range h.chatUsers[chatID] {
+		if !deliver(sub, event) {
+			h.dropUserSub(sub, errSubscriberLagging)
+		}
+	}
+}
+
+// SendToUser delivers an event to one user only: to the user's subscriptions
+// to the event's chat, to the user-level streams and to the direct
+// subscriptions, so every stream of the user gets it once.
+func (h *Hub) SendToUser(userID int64, event *chatv1.ChatEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	chatID := event.ChatId
+	for sub := range h.streams[chatID] {
+		if sub.userID == userID && !sub.skipDirect && !deliver(sub, event) {
+			h.dropChatSub(chatID, sub, errSubscriberLagging)
+		}
+	}
+
+	for sub := range h.users[userID] {
+		if !deliver(sub, event) {
+			h.dropUserSub(sub, errSubscriberLagging)
+		}
+	}
+
+	for sub := range h.direct[userID] {
+		if !deliver(sub, event) {
+			h.dropDirectSub(sub, errSubscriberLagging)
+		}
+	}
+}
+
+// dropChatSub must be called with h.mu held.
+func (h *Hub) dropChatSub(chatID int64, sub *Subscription, err error) {
+	removeSub(h.streams, chatID, sub)
+	sub.err = err
+	close(sub.ch)
+}
+
+// dropUserSub must be called with h.mu held.
+func (h *Hub) dropUserSub(sub *Subscription, err error) {
+	for chatID := range sub.chats {
+		removeSub(h.chatUsers, chatID, sub)
+	}
+	removeSub(h.users, sub.userID, sub)
+	sub.err = err
+	close(sub.ch)
+}
+
+// dropDirectSub must be called with h.mu held.
+func (h *Hub) dropDirectSub(sub *Subscription, err error) {
+	removeSub(h.direct, sub.userID, sub)
+	sub.err = err
+	close(sub.ch)
+}
+
+func deliver(sub *Subscription, event *chatv1.ChatEvent) bool {
+	select {
+	case sub.ch <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+func addSub(subs map[int64]map[*Subscription]struct{}, key int64, sub *Subscription) {
+	if subs[key] == nil {
+		subs[key] = make(map[*Subscription]struct{})
+	}
+	subs[key][sub] = struct{}{}
+}
+
+func removeSub(subs map[int64]map[*Subscription]struct{}, key int64, sub *Subscription) {
+	delete(subs[key], sub)
+	if len(subs[key]) == 0 {
+		delete(subs, key)
+	}
+}
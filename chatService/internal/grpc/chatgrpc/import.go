@@ -0,0 +1,80 @@
+package chatgrpc
+
+import (
+	"errors"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverApi) ImportChat(stream chatv1.ChatService_ImportChatServer) error {
+	userID, err := callerID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	res, err := s.chat.ImportChat(stream.Context(), userID, &importReader{stream: stream})
+	if err != nil {
+		if ctxErr := stream.Context().Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+
+		var unknown *services.UnknownUsersError
+		if errors.As(err, &unknown) {
+			return status.Error(codes.FailedPrecondition, unknown.Error())
+		}
+		var invalid *services.InvalidExportError
+		if errors.As(err, &invalid) {
+			return status.Error(codes.InvalidArgument, invalid.Error())
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return status.Error(codes.PermissionDenied, "permission denied")
+		}
+		if errors.Is(err, postgres.ErrDirectChatExists) {
+			return status.Error(codes.AlreadyExists, "direct chat already exists")
+		}
+		if errors.Is(err, postgres.ErrImportConflict) {
+			return status.Error(codes.Aborted, "the same chat is being imported concurrently")
+		}
+
+		return status.Error(codes.Internal, "failed to import chat")
+	}
+
+	if !res.AlreadyImported {
+		for _, memberID := range res.MemberIDs {
+			s.hub.Join(memberID, res.Chat.ID)
+		}
+	}
+
+	return stream.SendAndClose(&chatv1.ImportChatResponse{
+		Chat:               convert.ChatToProto(res.Chat),
+		AlreadyImported:    res.AlreadyImported,
+		ImportedMessages:   int32(res.Imported),
+		SkippedMessages:    int32(res.Skipped),
+		MissingAttachments: int32(res.MissingAttachments),
+	})
+}
+
+// importReader presents the chunks of an import stream as an io.Reader.
+type importReader struct {
+	stream chatv1.ChatService_ImportChatServer
+	buf    []byte
+}
+
+func (r *importReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,175 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *serverApi) AddMember(ctx context.Context, req *chatv1.AddMemberRequest) (*emptypb.Empty, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chat.AddMember(ctx, userID, req.GetChatId(), req.GetUserId()); err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+		if errors.Is(err, postgres.ErrMemberExists) {
+			return nil, status.Error(codes.AlreadyExists, "user is already a member")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to add member")
+	}
+
+	s.hub.Join(req.GetUserId(), req.GetChatId())
+	s.hub.Broadcast(convert.MemberJoinedEvent(req.GetChatId(), req.GetUserId()))
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) RemoveMember(ctx context.Context, req *chatv1.RemoveMemberRequest) (*emptypb.Empty, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chat.RemoveMember(ctx, userID, req.GetChatId(), req.GetUserId()); err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+		if errors.Is(err, postgres.ErrMemberNotFound) {
+			return nil, status.Error(codes.NotFound, "user is not a member")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to remove member")
+	}
+
+	s.hub.Broadcast(convert.MemberLeftEvent(req.GetChatId(), req.GetUserId()))
+	s.hub.Leave(req.GetUserId(), req.GetChatId())
+	s.presence.Recheck(ctx, req.GetUserId())
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) JoinChat(ctx context.Context, req *chatv1.JoinChatRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chat.JoinChat(ctx, userID, req.GetChatId()); err != nil {
+		if errors.Is(err, postgres.ErrChatNotFound) {
+			return nil, status.Error(codes.NotFound, "chat not found")
+		}
+		if errors.Is(err, postgres.ErrMemberExists) {
+			return nil, status.Error(codes.AlreadyExists, "already a member")
+		}
+		if errors.Is(err, services.ErrDirectChat) {
+			return nil, status.Error(codes.FailedPrecondition, "cannot join a direct chat")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "chat is not public")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to join chat")
+	}
+
+	s.hub.Join(userID, req.GetChatId())
+	s.hub.Broadcast(convert.MemberJoinedEvent(req.GetChatId(), userID))
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) LeaveChat(ctx context.Context, req *chatv1.LeaveChatRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chat.LeaveChat(ctx, userID, req.GetChatId()); err != nil {
+		if errors.Is(err, postgres.ErrMemberNotFound) {
+			return nil, status.Error(codes.NotFound, "not a chat member")
+		}
+		if errors.Is(err, services.ErrOwnerCannotLeave) {
+			return nil, status.Error(codes.FailedPrecondition, "owner cannot leave the chat")
+		}
+		if errors.Is(err, services.ErrDirectChat) {
+			return nil, status.Error(codes.FailedPrecondition, "cannot leave a direct chat")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to leave chat")
+	}
+
+	s.hub.Broadcast(convert.MemberLeftEvent(req.GetChatId(), userID))
+	s.hub.Leave(userID, req.GetChatId())
+	s.presence.Recheck(ctx, userID)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) ListMembers(ctx context.Context, req *chatv1.ListMembersRequest) (*chatv1.ListMembersResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.chat.ListMembers(ctx, userID, req.GetChatId())
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to list members")
+	}
+
+	return &chatv1.ListMembersResponse{Members: convert.ToProtoMemberList(members)}, nil
+}
+
+func (s *serverApi) SetMemberRole(ctx context.Context, req *chatv1.SetMemberRoleRequest) (*emptypb.Empty, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	role := convert.ProtoToRole(req.GetRole())
+	if role == "" {
+		return nil, status.Error(codes.InvalidArgument, "role is required")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chat.SetMemberRole(ctx, userID, req.GetChatId(), req.GetUserId(), role); err != nil {
+		if errors.Is(err, services.ErrInvalidRole) {
+			return nil, status.Error(codes.InvalidArgument, "role cannot be assigned")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+		if errors.Is(err, postgres.ErrMemberNotFound) {
+			return nil, status.Error(codes.NotFound, "user is not a member")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to set member role")
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,153 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *serverApi) EditMessage(ctx context.Context, req *chatv1.EditMessageRequest) (*chatv1.Message, error) {
+	text := req.GetText()
+	if text == "" {
+		return nil, status.Error(codes.InvalidArgument, "text is required")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, mentioned, err := s.chat.EditMessage(ctx, userID, req.GetMessageId(), text)
+	if err != nil {
+		if errors.Is(err, postgres.ErrMessageNotFound) {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		if errors.Is(err, postgres.ErrMessageDeleted) {
+			return nil, status.Error(codes.FailedPrecondition, "message is deleted")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to edit message")
+	}
+
+	s.hub.Broadcast(convert.MessageEditedEvent(msg))
+	for _, userID := range mentioned {
+		s.hub.SendToUser(userID, convert.MentionEvent(msg))
+	}
+
+	return convert.MessageToProto(msg), nil
+}
+
+func (s *serverApi) DeleteMessage(ctx context.Context, req *chatv1.DeleteMessageRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, unpinned, err := s.chat.DeleteMessage(ctx, userID, req.GetMessageId())
+	if err != nil {
+		if errors.Is(err, postgres.ErrMessageNotFound) {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		if errors.Is(err, postgres.ErrMessageDeleted) {
+			return nil, status.Error(codes.FailedPrecondition, "message is already deleted")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to delete message")
+	}
+
+	s.hub.Broadcast(convert.MessageDeletedEvent(msg))
+	if msg.Thread != nil {
+		s.hub.Broadcast(convert.ThreadUpdatedEvent(msg.ChatID, *msg.Thread))
+	}
+	if unpinned {
+		s.hub.Broadcast(convert.MessageUnpinnedEvent(msg, userID))
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) GetThread(ctx context.Context, req *chatv1.GetThreadRequest) (*chatv1.GetThreadResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	if req.GetCursor() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "cursor must not be negative")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	root, replies, next, err := s.chat.GetThread(
+		ctx,
+		userID,
+		req.GetMessageId(),
+		req.GetCursor(),
+		convert.ProtoToDirection(req.GetDirection()),
+		int(req.GetLimit()),
+	)
+	if err != nil {
+		if errors.Is(err, postgres.ErrMessageNotFound) {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to get thread")
+	}
+
+	return &chatv1.GetThreadResponse{
+		Root:       convert.MessageToProto(root),
+		Replies:    convert.ToProtoMessageList(replies),
+		NextCursor: next,
+	}, nil
+}
+
+func (s *serverApi) MarkRead(ctx context.Context, req *chatv1.MarkReadRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.markRead(ctx, userID, req); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) markRead(ctx context.Context, userID int64, req *chatv1.MarkReadRequest) error {
+	receipt, advanced, err := s.chat.MarkRead(ctx, userID, req.GetChatId(), req.GetMessageId())
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return status.Error(codes.PermissionDenied, "permission denied")
+		}
+		if errors.Is(err, postgres.ErrMessageNotFound) || errors.Is(err, services.ErrMessageNotInChat) {
+			return status.Error(codes.NotFound, "message not found")
+		}
+
+		return status.Error(codes.Internal, "failed to mark chat as read")
+	}
+
+	if advanced {
+		s.hub.Broadcast(convert.ReadReceiptEvent(receipt))
+	}
+
+	return nil
+}
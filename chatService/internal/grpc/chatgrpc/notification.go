@@ -0,0 +1,79 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *serverApi) ListNotifications(
+	ctx context.Context,
+	req *chatv1.ListNotificationsRequest,
+) (*chatv1.ListNotificationsResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	if req.GetCursor() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "cursor must not be negative")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, next, err := s.chat.ListNotifications(ctx, userID, req.GetCursor(), req.GetUnreadOnly(), int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list notifications")
+	}
+
+	return &chatv1.ListNotificationsResponse{
+		Notifications: convert.ToProtoNotificationList(notifications),
+		NextCursor:    next,
+	}, nil
+}
+
+func (s *serverApi) MarkNotificationRead(ctx context.Context, req *chatv1.MarkNotificationReadRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chat.MarkNotificationRead(ctx, userID, req.GetNotificationId()); err != nil {
+		if errors.Is(err, postgres.ErrNotificationNotFound) {
+			return nil, status.Error(codes.NotFound, "notification not found")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to mark notification read")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) SetHandle(ctx context.Context, req *chatv1.SetHandleRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chat.SetHandle(ctx, userID, req.GetHandle()); err != nil {
+		if errors.Is(err, services.ErrInvalidHandle) {
+			return nil, status.Error(codes.InvalidArgument, "invalid handle")
+		}
+		if errors.Is(err, postgres.ErrHandleTaken) {
+			return nil, status.Error(codes.AlreadyExists, "handle is taken")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to set handle")
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,95 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *serverApi) PinMessage(ctx context.Context, req *chatv1.PinMessageRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pin, err := s.chat.PinMessage(ctx, userID, req.GetMessageId())
+	if err != nil {
+		if errors.Is(err, postgres.ErrMessageNotFound) {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		if errors.Is(err, postgres.ErrMessageDeleted) {
+			return nil, status.Error(codes.FailedPrecondition, "message is deleted")
+		}
+		if errors.Is(err, postgres.ErrMessagePinned) {
+			return nil, status.Error(codes.AlreadyExists, "message already pinned")
+		}
+		if errors.Is(err, postgres.ErrPinLimitReached) {
+			return nil, status.Error(codes.ResourceExhausted, "too many pinned messages in the chat")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to pin message")
+	}
+
+	s.hub.Broadcast(convert.MessagePinnedEvent(pin))
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) UnpinMessage(ctx context.Context, req *chatv1.UnpinMessageRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := s.chat.UnpinMessage(ctx, userID, req.GetMessageId())
+	if err != nil {
+		if errors.Is(err, postgres.ErrMessageNotFound) {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		if errors.Is(err, postgres.ErrPinNotFound) {
+			return nil, status.Error(codes.NotFound, "message is not pinned")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to unpin message")
+	}
+
+	s.hub.Broadcast(convert.MessageUnpinnedEvent(msg, userID))
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) ListPinnedMessages(
+	ctx context.Context,
+	req *chatv1.ListPinnedMessagesRequest,
+) (*chatv1.ListPinnedMessagesResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pins, err := s.chat.ListPinnedMessages(ctx, userID, req.GetChatId())
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to list pinned messages")
+	}
+
+	return &chatv1.ListPinnedMessagesResponse{
+		Pins: convert.ToProtoPinList(pins),
+	}, nil
+}
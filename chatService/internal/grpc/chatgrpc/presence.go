@@ -0,0 +1,141 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const maxPresenceUsers = 500
+
+type Presence interface {
+	Connect(userID int64)
+	Disconnect(ctx context.Context, userID int64) error
+	SetAway(userID int64, away bool) error
+	GetPresence(ctx context.Context, viewerID int64, userIDs []int64) ([]models.Presence, error)
+	Watch(ctx context.Context, viewerID int64, userIDs []int64) (*services.PresenceWatcher, error)
+	Unwatch(w *services.PresenceWatcher)
+	Recheck(ctx context.Context, userID int64)
+}
+
+func (s *serverApi) SetPresence(ctx context.Context, req *chatv1.SetPresenceRequest) (*emptypb.Empty, error) {
+	var away bool
+	switch req.GetStatus() {
+	case chatv1.PresenceStatus_PRESENCE_STATUS_ONLINE:
+	case chatv1.PresenceStatus_PRESENCE_STATUS_AWAY:
+		away = true
+	default:
+		return nil, status.Error(codes.InvalidArgument, "status must be online or away")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.presence.SetAway(userID, away); err != nil {
+		if errors.Is(err, services.ErrNotConnected) {
+			return nil, status.Error(codes.FailedPrecondition, "no open stream")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to set presence")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) GetPresence(ctx context.Context, req *chatv1.GetPresenceRequest) (*chatv1.GetPresenceResponse, error) {
+	if err := validatePresenceUsers(req.GetUserIds()); err != nil {
+		return nil, err
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	presences, err := s.presence.GetPresence(ctx, userID, req.GetUserIds())
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to get presence")
+	}
+
+	return &chatv1.GetPresenceResponse{Presences: convert.ToProtoPresenceList(presences)}, nil
+}
+
+func (s *serverApi) WatchPresence(req *chatv1.WatchPresenceRequest, stream chatv1.ChatService_WatchPresenceServer) error {
+	userIDs := req.GetUserIds()
+	if err := validatePresenceUsers(userIDs); err != nil {
+		return err
+	}
+
+	userID, err := callerID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	// Watch before taking the snapshot so no change in between is lost.
+	watcher, err := s.presence.Watch(stream.Context(), userID, userIDs)
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return status.Error(codes.Internal, "failed to watch presence")
+	}
+	defer s.presence.Unwatch(watcher)
+
+	presences, err := s.presence.GetPresence(stream.Context(), userID, userIDs)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to get presence")
+	}
+
+	for _, presence := range presences {
+		if err := stream.Send(convert.PresenceToProto(presence)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Stream has ended")
+		case presence, ok := <-watcher.Changes():
+			if !ok {
+				return watcherError(watcher.Err())
+			}
+			if err := stream.Send(convert.PresenceToProto(presence)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watcherError maps the reason a presence watcher was dropped to the status
+// ending its stream.
+func watcherError(err error) error {
+	if errors.Is(err, services.ErrPermissionDenied) {
+		return status.Error(codes.PermissionDenied, "no longer sharing a chat with a watched user")
+	}
+	return status.Error(codes.ResourceExhausted, "too many undelivered presence changes, watch again")
+}
+
+func validatePresenceUsers(userIDs []int64) error {
+	if len(userIDs) == 0 {
+		return status.Error(codes.InvalidArgument, "user_ids is required")
+	}
+	if len(userIDs) > maxPresenceUsers {
+		return status.Errorf(codes.InvalidArgument, "at most %d user_ids are allowed", maxPresenceUsers)
+	}
+	return nil
+}
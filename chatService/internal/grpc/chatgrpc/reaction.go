@@ -0,0 +1,94 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// maxEmojiLength bounds the emoji in bytes, enough for multi-codepoint sequences.
+const maxEmojiLength = 32
+
+func (s *serverApi) AddReaction(ctx context.Context, req *chatv1.AddReactionRequest) (*emptypb.Empty, error) {
+	if err := validateEmoji(req.GetEmoji()); err != nil {
+		return nil, err
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := s.chat.AddReaction(ctx, userID, req.GetMessageId(), req.GetEmoji())
+	if err != nil {
+		if errors.Is(err, postgres.ErrMessageNotFound) {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		if errors.Is(err, postgres.ErrMessageDeleted) {
+			return nil, status.Error(codes.FailedPrecondition, "message is deleted")
+		}
+		if errors.Is(err, postgres.ErrReactionExists) {
+			return nil, status.Error(codes.AlreadyExists, "reaction already exists")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to add reaction")
+	}
+
+	s.hub.Broadcast(convert.ReactionsChangedEvent(msg))
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) RemoveReaction(ctx context.Context, req *chatv1.RemoveReactionRequest) (*emptypb.Empty, error) {
+	if err := validateEmoji(req.GetEmoji()); err != nil {
+		return nil, err
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := s.chat.RemoveReaction(ctx, userID, req.GetMessageId(), req.GetEmoji())
+	if err != nil {
+		if errors.Is(err, postgres.ErrMessageNotFound) {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		if errors.Is(err, postgres.ErrMessageDeleted) {
+			return nil, status.Error(codes.FailedPrecondition, "message is deleted")
+		}
+		if errors.Is(err, postgres.ErrReactionNotFound) {
+			return nil, status.Error(codes.NotFound, "reaction not found")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to remove reaction")
+	}
+
+	s.hub.Broadcast(convert.ReactionsChangedEvent(msg))
+
+	return &emptypb.Empty{}, nil
+}
+
+func validateEmoji(emoji string) error {
+	if emoji == "" {
+		return status.Error(codes.InvalidArgument, "emoji is required")
+	}
+	if len(emoji) > maxEmojiLength || !utf8.ValidString(emoji) {
+		return status.Error(codes.InvalidArgument, "invalid emoji")
+	}
+	return nil
+}
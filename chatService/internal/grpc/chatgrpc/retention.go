@@ -0,0 +1,64 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *serverApi) SetRetention(ctx context.Context, req *chatv1.SetRetentionRequest) (*emptypb.Empty, error) {
+	retention := convert.ProtoToRetention(req.GetRetention())
+	if err := services.ValidateRetention(retention); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid retention")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chat.SetRetention(ctx, userID, req.GetChatId(), retention); err != nil {
+		if errors.Is(err, postgres.ErrChatNotFound) {
+			return nil, status.Error(codes.NotFound, "chat not found")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to set retention")
+	}
+
+	s.hub.Broadcast(convert.RetentionChangedEvent(req.GetChatId(), retention, userID))
+
+	return &emptypb.Empty{}, nil
+}
+
+// MessagesExpired tells the streams of each chat which of its messages the
+// retention janitor removed, as ordinary deletions, which of them were
+// unpinned with it, with no unpinned_by, and the new counters of the thread
+// roots whose replies were removed.
+func (h *Hub) MessagesExpired(expired models.Expired) {
+	for _, msg := range expired.Messages {
+		h.Broadcast(convert.MessageDeletedEvent(msg))
+	}
+
+	for _, msg := range expired.Unpinned {
+		h.Broadcast(convert.MessageUnpinnedEvent(msg, 0))
+	}
+
+	for _, root := range expired.Roots {
+		thread := models.Thread{RootID: root.ID, ReplyCount: root.ReplyCount}
+		if root.LastReplyAt != nil {
+			thread.LastReplyAt = *root.LastReplyAt
+		}
+		h.Broadcast(convert.ThreadUpdatedEvent(root.ChatID, thread))
+	}
+}
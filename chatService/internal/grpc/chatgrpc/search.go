@@ -0,0 +1,71 @@
+package chatgrpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxSearchQueryLength = 256
+	maxSearchChats       = 100
+)
+
+func (s *serverApi) SearchMessages(ctx context.Context, req *chatv1.SearchMessagesRequest) (*chatv1.SearchMessagesResponse, error) {
+	query := strings.TrimSpace(req.GetQuery())
+	if query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	if len(query) > maxSearchQueryLength {
+		return nil, status.Errorf(codes.InvalidArgument, "query must be at most %d bytes", maxSearchQueryLength)
+	}
+
+	if len(req.GetChatIds()) > maxSearchChats {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d chat_ids are allowed", maxSearchChats)
+	}
+
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	if req.GetCursor() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "cursor must not be negative")
+	}
+
+	filter := models.SearchFilter{
+		Query:    query,
+		ChatIDs:  req.GetChatIds(),
+		SenderID: req.GetSenderId(),
+	}
+	if req.GetFrom() != nil {
+		from := req.GetFrom().AsTime()
+		filter.From = &from
+	}
+	if req.GetTo() != nil {
+		to := req.GetTo().AsTime()
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results, next, err := s.chat.SearchMessages(ctx, userID, filter, req.GetCursor(), int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to search messages")
+	}
+
+	return &chatv1.SearchMessagesResponse{
+		Results:    convert.ToProtoSearchResults(results),
+		NextCursor: next,
+	}, nil
+}
@@ -2,9 +2,14 @@ package chatgrpc
 
 import (
 	"context"
-	"slices"
-	"sync"
+	"errors"
+	"io"
 
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/Gilf4/grpcChat/chat/internal/grpc/interceptors"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
 	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -12,77 +17,227 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-type Hub struct {
-	mu      sync.RWMutex
-	streams map[int64][]chan *chatv1.Message
+const replayPageSize = 100
+
+type Chat interface {
+	CreateChat(ctx context.Context, userID int64, name string, public bool) (models.Chat, error)
+	GetOrCreateDirectChat(ctx context.Context, userID, peerID int64) (models.Chat, error)
+	SetRetention(ctx context.Context, userID, chatID int64, retention models.Retention) error
+	ExportChat(ctx context.Context, userID, chatID int64, w io.Writer) error
+	ImportChat(ctx context.Context, userID int64, r io.Reader) (models.ImportResult, error)
+	GetChatList(ctx context.Context, userID int64) ([]models.Chat, error)
+	Authorize(ctx context.Context, chatID, userID int64, perm services.Permission) (models.Member, error)
+	SendMessage(ctx context.Context, chatID, senderID int64, text string, replyToID int64, attachmentIDs []int64) (models.Message, error)
+	UploadAttachment(ctx context.Context, userID, chatID int64, filename string, r io.Reader) (models.Attachment, error)
+	DownloadAttachment(ctx context.Context, userID, attachmentID, thumbnailID int64) (models.Attachment, io.ReadCloser, error)
+	EditMessage(ctx context.Context, userID, messageID int64, text string) (models.Message, []int64, error)
+	DeleteMessage(ctx context.Context, userID, messageID int64) (models.Message, bool, error)
+	MarkRead(ctx context.Context, userID, chatID, messageID int64) (models.ReadReceipt, bool, error)
+	AddReaction(ctx context.Context, userID, messageID int64, emoji string) (models.Message, error)
+	RemoveReaction(ctx context.Context, userID, messageID int64, emoji string) (models.Message, error)
+	PinMessage(ctx context.Context, userID, messageID int64) (models.Pin, error)
+	UnpinMessage(ctx context.Context, userID, messageID int64) (models.Message, error)
+	ListPinnedMessages(ctx context.Context, userID, chatID int64) ([]models.Pin, error)
+	GetMessages(ctx context.Context, userID, chatID, cursor int64, direction models.Direction, limit int) ([]models.Message, int64, error)
+	ResumeSeq(ctx context.Context, userID, chatID, lastSeenID int64) (int64, error)
+	MessagesAfter(ctx context.Context, userID, chatID, afterSeq int64, limit int) ([]models.Message, error)
+	ListNotifications(ctx context.Context, userID, cursor int64, unreadOnly bool, limit int) ([]models.Notification, int64, error)
+	MarkNotificationRead(ctx context.Context, userID, notificationID int64) error
+	SetHandle(ctx context.Context, userID int64, handle string) error
+	SearchMessages(ctx context.Context, userID int64, filter models.SearchFilter, cursor int64, limit int) ([]models.SearchResult, int64, error)
+	GetThread(ctx context.Context, userID, messageID, cursor int64, direction models.Direction, limit int) (models.Message, []models.Message, int64, error)
+	AddMember(ctx context.Context, callerID, chatID, userID int64) error
+	RemoveMember(ctx context.Context, callerID, chatID, userID int64) error
+	JoinChat(ctx context.Context, userID, chatID int64) error
+	LeaveChat(ctx context.Context, userID, chatID int64) error
+	ListMembers(ctx context.Context, callerID, chatID int64) ([]models.Member, error)
+	ChatIDs(ctx context.Context, userID int64) ([]int64, error)
+	SetMemberRole(ctx context.Context, callerID, chatID, userID int64, role models.Role) error
 }
 
-func NewHub() *Hub {
-	return &Hub{
-		streams: make(map[int64][]chan *chatv1.Message),
-	}
+type serverApi struct {
+	chatv1.UnimplementedChatServiceServer
+
+	chat     Chat
+	presence Presence
+	hub      *Hub
+	typing   *Typing
 }
 
-func (h *Hub) Subscribe(chatID int64) <-chan *chatv1.Message {
-	ch := make(chan *chatv1.Message, 10)
-	h.mu.Lock()
-	h.streams[chatID] = append(h.streams[chatID], ch)
-	h.mu.Unlock()
-	return ch
+func Register(gRPCServer *grpc.Server, hub *Hub, chat Chat, presence Presence) {
+	chatv1.RegisterChatServiceServer(gRPCServer, &serverApi{
+		chat:     chat,
+		presence: presence,
+		hub:      hub,
+		typing:   NewTyping(hub, typingTTL),
+	})
 }
 
-func (h *Hub) Unsubscribe(chatID int64, sub <-chan *chatv1.Message) {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-	subs := h.streams[chatID]
-	for i, c := range subs {
-		if c == sub {
-			h.streams[chatID] = slices.Delete(subs, i, i+1)
-			close(c)
-			break
-		}
+func (s *serverApi) CreateChat(ctx context.Context, req *chatv1.CreateChatRequest) (*chatv1.CreateChatResponse, error) {
+	name := req.GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chat, err := s.chat.CreateChat(ctx, userID, name, req.GetPublic())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to create chat")
 	}
+
+	s.hub.Join(userID, chat.ID)
+
+	return &chatv1.CreateChatResponse{Chat: convert.ChatToProto(chat)}, nil
 }
 
-func (h *Hub) Broadcast(msg *chatv1.Message) {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
+func (s *serverApi) GetOrCreateDirectChat(
+	ctx context.Context,
+	req *chatv1.GetOrCreateDirectChatRequest,
+) (*chatv1.GetOrCreateDirectChatResponse, error) {
+	if req.GetPeerUserId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "peer_user_id is required")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	subs := h.streams[msg.ChatId]
-	for _, ch := range subs {
-		select {
-		case ch <- msg:
-		default:
-			continue
+	chat, err := s.chat.GetOrCreateDirectChat(ctx, userID, req.GetPeerUserId())
+	if err != nil {
+		if errors.Is(err, services.ErrSelfDirectChat) {
+			return nil, status.Error(codes.InvalidArgument, "cannot start a direct chat with yourself")
 		}
+		if errors.Is(err, postgres.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to get direct chat")
 	}
-}
 
-type serverApi struct {
-	chatv1.UnimplementedChatServiceServer
+	s.hub.Join(userID, chat.ID)
+	s.hub.Join(chat.PeerID, chat.ID)
 
-	hub *Hub
-	msg chan *chatv1.Message
+	return &chatv1.GetOrCreateDirectChatResponse{Chat: convert.ChatToProto(chat)}, nil
 }
 
-func Register(gRPCServer *grpc.Server) {
-	chatv1.RegisterChatServiceServer(gRPCServer, &serverApi{
-		msg: make(chan *chatv1.Message, 100),
-		hub: NewHub(),
-	})
+func (s *serverApi) GetChatList(ctx context.Context, _ *emptypb.Empty) (*chatv1.GetChatListResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chats, err := s.chat.GetChatList(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get chat list")
+	}
+
+	return &chatv1.GetChatListResponse{Chats: convert.ToProtoChatList(chats)}, nil
 }
 
 func (s *serverApi) ConnectChat(req *chatv1.ConnectChatRequest, stream chatv1.ChatService_ConnectChatServer) error {
 	chatID := req.GetId()
-	sub := s.hub.Subscribe(chatID)
+
+	userID, err := callerID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	// Subscribe before checking membership: a removal committed after the
+	// check closes the subscription, one committed before fails the check.
+	// Subscribing before replaying also keeps anything broadcast during the
+	// replay; created events the replay already delivered are skipped by seq.
+	sub := s.hub.Subscribe(userID, chatID)
 	defer s.hub.Unsubscribe(chatID, sub)
 
+	if err := s.authorizeRead(stream.Context(), userID, chatID); err != nil {
+		return err
+	}
+
+	s.presence.Connect(userID)
+	defer s.presence.Disconnect(context.WithoutCancel(stream.Context()), userID)
+
+	var replayedUpTo int64
+	if lastSeen := req.GetLastSeenMessageId(); lastSeen > 0 {
+		replayedUpTo, err = s.replay(stream.Context(), userID, chatID, lastSeen, stream.Send)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.forward(stream.Context(), sub, userID, replayedUpTo, stream.Send)
+}
+
+func (s *serverApi) authorizeRead(ctx context.Context, userID, chatID int64) error {
+	if _, err := s.chat.Authorize(ctx, chatID, userID, services.PermReadMessages); err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return status.Error(codes.Internal, "failed to connect to chat")
+	}
+	return nil
+}
+
+// replay sends the stored messages of a chat that follow lastSeen, oldest
+// first, until it has caught up with the chat, and returns the seq of the
+// last message sent.
+func (s *serverApi) replay(
+	ctx context.Context,
+	userID, chatID, lastSeen int64,
+	send func(*chatv1.ChatEvent) error,
+) (int64, error) {
+	seq, err := s.chat.ResumeSeq(ctx, userID, chatID, lastSeen)
+	if err != nil {
+		return 0, status.Error(codes.Internal, "failed to replay messages")
+	}
+
+	for {
+		msgs, err := s.chat.MessagesAfter(ctx, userID, chatID, seq, replayPageSize)
+		if err != nil {
+			return 0, status.Error(codes.Internal, "failed to replay messages")
+		}
+		if len(msgs) == 0 {
+			return seq, nil
+		}
+
+		for _, msg := range msgs {
+			if err := send(convert.MessageCreatedEvent(msg)); err != nil {
+				return 0, err
+			}
+			seq = msg.Seq
+		}
+	}
+}
+
+// forward relays live events of a subscription until ctx is done or the hub
+// drops the subscription. Created events up to seq replayedUpTo and the
+// user's own typing events are skipped.
+func (s *serverApi) forward(
+	ctx context.Context,
+	sub *Subscription,
+	userID, replayedUpTo int64,
+	send func(*chatv1.ChatEvent) error,
+) error {
 	for {
 		select {
-		case <-stream.Context().Done():
+		case <-ctx.Done():
 			return status.Error(codes.Canceled, "Stream has ended")
-		case msg := <-sub:
-			if err := stream.Send(msg); err != nil {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return sub.Err()
+			}
+			if created := event.GetMessageCreated(); created != nil && created.GetSeq() <= replayedUpTo {
+				continue
+			}
+			if typing := event.GetTyping(); typing != nil && typing.GetUserId() == userID {
+				continue
+			}
+			if err := send(event); err != nil {
 				return err
 			}
 		}
@@ -90,13 +245,105 @@ func (s *serverApi) ConnectChat(req *chatv1.ConnectChatRequest, stream chatv1.Ch
 }
 
 func (s *serverApi) SendMessage(ctx context.Context, req *chatv1.SendMessageRequest) (*emptypb.Empty, error) {
-	msg := &chatv1.Message{
-		ChatId:   req.GetChatId(),
-		SenderId: req.GetSenderId(),
-		Text:     req.GetText(),
+	senderID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	s.hub.Broadcast(msg)
+	if _, err := s.sendMessage(ctx, senderID, req); err != nil {
+		return nil, err
+	}
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *serverApi) sendMessage(ctx context.Context, senderID int64, req *chatv1.SendMessageRequest) (models.Message, error) {
+	text := req.GetText()
+	if text == "" && len(req.GetAttachmentIds()) == 0 {
+		return models.Message{}, status.Error(codes.InvalidArgument, "text is required")
+	}
+
+	if err := validateAttachmentIDs(req.GetAttachmentIds()); err != nil {
+		return models.Message{}, err
+	}
+
+	if req.GetReplyToMessageId() < 0 {
+		return models.Message{}, status.Error(codes.InvalidArgument, "reply_to_message_id must not be negative")
+	}
+
+	msg, err := s.chat.SendMessage(ctx, req.GetChatId(), senderID, text, req.GetReplyToMessageId(), req.GetAttachmentIds())
+	if err != nil {
+		if errors.Is(err, postgres.ErrMessageNotFound) {
+			return models.Message{}, status.Error(codes.NotFound, "reply target not found")
+		}
+		if errors.Is(err, services.ErrInvalidReply) {
+			return models.Message{}, status.Error(codes.InvalidArgument, "reply target is in another chat")
+		}
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return models.Message{}, status.Error(codes.PermissionDenied, "permission denied")
+		}
+		if errors.Is(err, postgres.ErrChatNotFound) {
+			return models.Message{}, status.Error(codes.NotFound, "chat not found")
+		}
+		if errors.Is(err, postgres.ErrAttachmentNotFound) {
+			return models.Message{}, status.Error(codes.NotFound, "attachment not found")
+		}
+
+		return models.Message{}, status.Error(codes.Internal, "failed to send message")
+	}
+
+	s.typing.Stop(msg.ChatID, senderID)
+	s.hub.Broadcast(convert.MessageCreatedEvent(msg))
+	if msg.Thread != nil {
+		s.hub.Broadcast(convert.ThreadUpdatedEvent(msg.ChatID, *msg.Thread))
+	}
+	for _, userID := range msg.MentionedUserIDs {
+		s.hub.SendToUser(userID, convert.MentionEvent(msg))
+	}
+
+	return msg, nil
+}
+
+func (s *serverApi) GetMessages(ctx context.Context, req *chatv1.GetMessagesRequest) (*chatv1.GetMessagesResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	if req.GetCursor() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "cursor must not be negative")
+	}
+
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, next, err := s.chat.GetMessages(
+		ctx,
+		userID,
+		req.GetChatId(),
+		req.GetCursor(),
+		convert.ProtoToDirection(req.GetDirection()),
+		int(req.GetLimit()),
+	)
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return nil, status.Error(codes.Internal, "failed to get messages")
+	}
+
+	return &chatv1.GetMessagesResponse{
+		Messages:   convert.ToProtoMessageList(msgs),
+		NextCursor: next,
+	}, nil
+}
+
+func callerID(ctx context.Context) (int64, error) {
+	userID, ok := interceptors.UserID(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	return userID, nil
+}
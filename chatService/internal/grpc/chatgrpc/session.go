@@ -0,0 +1,237 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxSessionChats   = 100
+	sessionBufferSize = 64
+)
+
+// session is the state of one Session stream. Requests are handled one at a
+// time by the receiving goroutine; every response goes through out so that a
+// single goroutine writes to the stream. A chat subscription the hub drops is
+// removed from subs by its forwarding goroutine; if the user fell behind on
+// it or on the session's direct subscription, the reason is reported on
+// failed, which ends the session.
+type session struct {
+	server *serverApi
+	ctx    context.Context
+	userID int64
+	out    chan *chatv1.SessionResponse
+	failed chan error
+
+	mu   sync.Mutex
+	subs map[int64]*Subscription
+}
+
+func (s *serverApi) Session(stream chatv1.ChatService_SessionServer) error {
+	userID, err := callerID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	s.presence.Connect(userID)
+	defer s.presence.Disconnect(context.WithoutCancel(stream.Context()), userID)
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	sess := &session{
+		server: s,
+		ctx:    ctx,
+		userID: userID,
+		out:    make(chan *chatv1.SessionResponse, sessionBufferSize),
+		failed: make(chan error, 1),
+		subs:   make(map[int64]*Subscription),
+	}
+
+	// Events sent to the user, such as mentions, reach the session whatever
+	// chats it follows.
+	direct := s.hub.SubscribeDirect(userID)
+	defer s.hub.UnsubscribeDirect(userID, direct)
+	go func() {
+		err := s.forward(sess.ctx, direct, userID, 0, sess.sendEvent)
+		if errors.Is(err, errSubscriberLagging) {
+			sess.fail(err)
+		}
+	}()
+
+	recvErr := make(chan error, 1)
+	go func() {
+		recvErr <- sess.receive(stream)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, "Stream has ended")
+		case err := <-sess.failed:
+			return err
+		case err := <-recvErr:
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			// The client closed its side; its subscriptions keep delivering
+			// until the stream's context ends.
+			recvErr = nil
+		case resp := <-sess.out:
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (sess *session) receive(stream chatv1.ChatService_SessionServer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		if err := sess.handle(req); err != nil {
+			return err
+		}
+	}
+}
+
+// handle performs one request and queues its ack. Only a failure to queue the
+// ack is returned; request errors are reported in the ack.
+func (sess *session) handle(req *chatv1.SessionRequest) error {
+	s := sess.server
+	ack := &chatv1.SessionAck{RequestId: req.GetRequestId()}
+
+	var err error
+	switch action := req.GetAction().(type) {
+	case *chatv1.SessionRequest_Subscribe:
+		err = sess.subscribe(action.Subscribe)
+	case *chatv1.SessionRequest_Unsubscribe:
+		err = sess.unsubscribe(action.Unsubscribe.GetChatId())
+	case *chatv1.SessionRequest_SendMessage:
+		msg, sendErr := s.sendMessage(sess.ctx, sess.userID, action.SendMessage)
+		if sendErr == nil {
+			ack.Message = convert.MessageToProto(msg)
+		}
+		err = sendErr
+	case *chatv1.SessionRequest_Typing:
+		err = s.notifyTyping(sess.ctx, sess.userID, action.Typing)
+	case *chatv1.SessionRequest_MarkRead:
+		err = s.markRead(sess.ctx, sess.userID, action.MarkRead)
+	default:
+		err = status.Error(codes.InvalidArgument, "action is required")
+	}
+
+	if err != nil {
+		st := status.Convert(err)
+		ack.Code = int32(st.Code())
+		ack.Error = st.Message()
+	}
+
+	return sess.send(&chatv1.SessionResponse{Payload: &chatv1.SessionResponse_Ack{Ack: ack}})
+}
+
+// subscribe replays the requested history, if any, before returning, so the
+// replayed events precede the ack. Live events follow from a goroutine that
+// runs until the chat is unsubscribed, the user leaves it or the session ends.
+func (sess *session) subscribe(req *chatv1.SubscribeChat) error {
+	s := sess.server
+	chatID := req.GetChatId()
+
+	sess.mu.Lock()
+	_, subscribed := sess.subs[chatID]
+	count := len(sess.subs)
+	sess.mu.Unlock()
+
+	if subscribed {
+		return status.Error(codes.AlreadyExists, "already subscribed")
+	}
+	if count >= maxSessionChats {
+		return status.Errorf(codes.ResourceExhausted, "at most %d chats per session", maxSessionChats)
+	}
+
+	// Subscribe before checking membership, as ConnectChat does.
+	sub := s.hub.SubscribeSession(sess.userID, chatID)
+
+	if err := s.authorizeRead(sess.ctx, sess.userID, chatID); err != nil {
+		s.hub.Unsubscribe(chatID, sub)
+		return err
+	}
+
+	var replayedUpTo int64
+	if lastSeen := req.GetLastSeenMessageId(); lastSeen > 0 {
+		var err error
+		replayedUpTo, err = s.replay(sess.ctx, sess.userID, chatID, lastSeen, sess.sendEvent)
+		if err != nil {
+			s.hub.Unsubscribe(chatID, sub)
+			return err
+		}
+	}
+
+	sess.mu.Lock()
+	sess.subs[chatID] = sub
+	sess.mu.Unlock()
+
+	go func() {
+		defer s.hub.Unsubscribe(chatID, sub)
+
+		err := s.forward(sess.ctx, sub, sess.userID, replayedUpTo, sess.sendEvent)
+
+		sess.mu.Lock()
+		if sess.subs[chatID] == sub {
+			delete(sess.subs, chatID)
+		}
+		sess.mu.Unlock()
+
+		if errors.Is(err, errSubscriberLagging) {
+			sess.fail(err)
+		}
+	}()
+
+	return nil
+}
+
+func (sess *session) unsubscribe(chatID int64) error {
+	sess.mu.Lock()
+	sub, ok := sess.subs[chatID]
+	delete(sess.subs, chatID)
+	sess.mu.Unlock()
+
+	if !ok {
+		return status.Error(codes.FailedPrecondition, "not subscribed")
+	}
+
+	sess.server.hub.Unsubscribe(chatID, sub)
+
+	return nil
+}
+
+// fail ends the session with err unless another failure got there first.
+func (sess *session) fail(err error) {
+	select {
+	case sess.failed <- err:
+	default:
+	}
+}
+
+func (sess *session) sendEvent(event *chatv1.ChatEvent) error {
+	return sess.send(&chatv1.SessionResponse{Payload: &chatv1.SessionResponse_Event{Event: event}})
+}
+
+func (sess *session) send(resp *chatv1.SessionResponse) error {
+	select {
+	case sess.out <- resp:
+		return nil
+	case <-sess.ctx.Done():
+		return sess.ctx.Err()
+	}
+}
@@ -0,0 +1,118 @@
+package chatgrpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/convert"
+	"github.com/Gilf4/grpcChat/chat/internal/services"
+	chatv1 "github.com/Gilf4/grpcChat/protos/gen/go/chat/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// typingTTL is how long a typing indicator lives without being renewed.
+const typingTTL = 5 * time.Second
+
+type typingKey struct {
+	chatID int64
+	userID int64
+}
+
+// Typing keeps the in-memory typing indicators and broadcasts their changes
+// through the Hub. Nothing here is persisted.
+type Typing struct {
+	hub *Hub
+	ttl time.Duration
+
+	mu     sync.Mutex
+	timers map[typingKey]*time.Timer
+}
+
+func NewTyping(hub *Hub, ttl time.Duration) *Typing {
+	return &Typing{
+		hub:    hub,
+		ttl:    ttl,
+		timers: make(map[typingKey]*time.Timer),
+	}
+}
+
+// Start announces that the user is typing, or renews the indicator if it is
+// already shown. The indicator expires after the TTL unless renewed again.
+func (t *Typing) Start(chatID, userID int64) {
+	key := typingKey{chatID: chatID, userID: userID}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if timer, ok := t.timers[key]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(t.ttl, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		// The indicator may have been renewed or stopped in the meantime.
+		if t.timers[key] != timer {
+			return
+		}
+		delete(t.timers, key)
+		t.hub.Broadcast(convert.TypingEvent(chatID, userID, false, time.Time{}))
+	})
+	t.timers[key] = timer
+
+	t.hub.Broadcast(convert.TypingEvent(chatID, userID, true, time.Now().Add(t.ttl)))
+}
+
+// Stop clears the user's typing indicator. It does nothing if none is shown.
+func (t *Typing) Stop(chatID, userID int64) {
+	key := typingKey{chatID: chatID, userID: userID}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timer, ok := t.timers[key]
+	if !ok {
+		return
+	}
+	timer.Stop()
+	delete(t.timers, key)
+
+	t.hub.Broadcast(convert.TypingEvent(chatID, userID, false, time.Time{}))
+}
+
+func (s *serverApi) NotifyTyping(ctx context.Context, req *chatv1.NotifyTypingRequest) (*emptypb.Empty, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.notifyTyping(ctx, userID, req); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverApi) notifyTyping(ctx context.Context, userID int64, req *chatv1.NotifyTypingRequest) error {
+	if _, err := s.chat.Authorize(ctx, req.GetChatId(), userID, services.PermSendMessages); err != nil {
+		if errors.Is(err, services.ErrPermissionDenied) {
+			return status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return status.Error(codes.Internal, "failed to notify typing")
+	}
+
+	if req.GetTyping() {
+		s.typing.Start(req.GetChatId(), userID)
+	} else {
+		s.typing.Stop(req.GetChatId(), userID)
+	}
+
+	return nil
+}
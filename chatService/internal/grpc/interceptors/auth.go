@@ -0,0 +1,91 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Gilf4/grpcChat/chat/internal/lib/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// reflectionPrefix exempts the reflection service so tools like grpcurl keep working.
+const reflectionPrefix = "/grpc.reflection."
+
+type userIDKey struct{}
+
+// UserID returns the id of the authenticated caller.
+func UserID(ctx context.Context) (int64, bool) {
+	id, ok := ctx.Value(userIDKey{}).(int64)
+	return id, ok
+}
+
+// WithUserID returns a copy of ctx carrying the authenticated caller id.
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+func UnaryAuth(secret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, reflectionPrefix) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamAuth(secret string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, reflectionPrefix) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), secret)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, secret string) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing access token")
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization header")
+	}
+
+	userID, err := jwt.ParseToken(token, secret)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid access token")
+	}
+
+	return WithUserID(ctx, userID), nil
+}
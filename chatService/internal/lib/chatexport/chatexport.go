@@ -0,0 +1,218 @@
+// Package chatexport defines the NDJSON format chats are exported in.
+//
+// An export is a sequence of JSON objects, one per line, each carrying a
+// "type" field. Lines appear in this order:
+//
+//	header   exactly one, first: the format name and version
+//	chat     exactly one: the chat's metadata and retention policy
+//	user     one per user the chat refers to, members or not
+//	member   one per member at the time of the export
+//	blob     the contents of the attachments of the message line that follows
+//	message  the whole history by ascending seq, tombstones included
+//	pin      one per pinned message
+//	end      exactly one, last: how many lines of each type came before
+//
+// An export without its end line is truncated and must not be trusted. All
+// ids are those of the exporting environment; users are best matched across
+// environments by email, which only operator dumps carry: exports requested by
+// users leave it out. Times are RFC 3339 in UTC. Attachments name their blob
+// by storage key, and its contents come in blob lines with that key right
+// before the message line. An attachment whose blob was missing when the chat
+// was exported has no blob lines.
+//
+// Readers should ignore unknown fields and line types, so that the format can
+// grow without a version bump.
+package chatexport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+const (
+	Format  = "grpcchat.chat"
+	Version = 1
+)
+
+// Line types.
+const (
+	TypeHeader  = "header"
+	TypeChat    = "chat"
+	TypeUser    = "user"
+	TypeMember  = "member"
+	TypeBlob    = "blob"
+	TypeMessage = "message"
+	TypePin     = "pin"
+	TypeEnd     = "end"
+)
+
+type Header struct {
+	Type       string    `json:"type"`
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+type Chat struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Kind is "group" or "direct".
+	Kind      string    `json:"kind"`
+	Retention Retention `json:"retention"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Retention struct {
+	// Mode is "forever", "days" or "disappearing".
+	Mode       string `json:"mode"`
+	TTLSeconds int64  `json:"ttl_seconds,omitempty"`
+}
+
+type User struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+	// Email is absent from exports requested by users.
+	Email string `json:"email,omitempty"`
+	Name  string `json:"name"`
+}
+
+type Member struct {
+	Type   string `json:"type"`
+	UserID int64  `json:"user_id"`
+	// Role is "owner", "admin", "member" or "read_only".
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// BlobChunkSize is the most data a single blob line carries.
+const BlobChunkSize = 1 << 20
+
+// Blob carries part of the contents of an attachment. The contents of one
+// attachment are split over consecutive blob lines with its storage key, in
+// order. Data is base64 encoded in JSON.
+type Blob struct {
+	Type       string `json:"type"`
+	StorageKey string `json:"storage_key"`
+	Data       []byte `json:"data"`
+}
+
+type Message struct {
+	Type      string     `json:"type"`
+	ID        int64      `json:"id"`
+	Seq       int64      `json:"seq"`
+	SenderID  int64      `json:"sender_id"`
+	Text      string     `json:"text"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// ReplyToID is the id of the thread root, absent for top-level messages.
+	ReplyToID        int64      `json:"reply_to_id,omitempty"`
+	MentionedUserIDs []int64    `json:"mentioned_user_ids,omitempty"`
+	Reactions        []Reaction `json:"reactions,omitempty"`
+	// Attachments are absent from deleted messages.
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+type Reaction struct {
+	UserID    int64     `json:"user_id"`
+	Emoji     string    `json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Attachment references a blob of the exporting environment, whose contents
+// precede the message line in blob lines.
+type Attachment struct {
+	ID          int64     `json:"id"`
+	UploaderID  int64     `json:"uploader_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Width       int       `json:"width,omitempty"`
+	Height      int       `json:"height,omitempty"`
+	StorageKey  string    `json:"storage_key"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Pin struct {
+	Type      string    `json:"type"`
+	MessageID int64     `json:"message_id"`
+	PinnedBy  int64     `json:"pinned_by"`
+	PinnedAt  time.Time `json:"pinned_at"`
+}
+
+type End struct {
+	Type     string `json:"type"`
+	Users    int    `json:"users"`
+	Members  int    `json:"members"`
+	Blobs    int    `json:"blobs"`
+	Messages int    `json:"messages"`
+	Pins     int    `json:"pins"`
+}
+
+// Encoder writes lines of an export.
+type Encoder struct {
+	enc *json.Encoder
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &Encoder{enc: enc}
+}
+
+// Encode writes line as a single line of JSON. line must be one of the line
+// types of this package with its Type set.
+func (e *Encoder) Encode(line any) error {
+	return e.enc.Encode(line)
+}
+
+// maxLineSize bounds a single line of an export being decoded.
+const maxLineSize = 4 << 20
+
+// Decoder reads the lines of an export one at a time.
+type Decoder struct {
+	sc   *bufio.Scanner
+	line []byte
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), maxLineSize)
+	return &Decoder{sc: sc}
+}
+
+// Next advances to the next non-empty line and returns its type. It returns
+// io.EOF once the input is exhausted.
+func (d *Decoder) Next() (string, error) {
+	for d.sc.Scan() {
+		d.line = bytes.TrimSpace(d.sc.Bytes())
+		if len(d.line) == 0 {
+			continue
+		}
+
+		var head struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(d.line, &head); err != nil {
+			return "", err
+		}
+		if head.Type == "" {
+			return "", errors.New("line without a type")
+		}
+		return head.Type, nil
+	}
+	if err := d.sc.Err(); err != nil {
+		return "", err
+	}
+	return "", io.EOF
+}
+
+// Decode unmarshals the current line into v.
+func (d *Decoder) Decode(v any) error {
+	return json.Unmarshal(d.line, v)
+}
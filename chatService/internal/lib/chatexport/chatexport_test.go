@@ -0,0 +1,179 @@
+package chatexport
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncoder(t *testing.T) {
+	at := time.Date(2025, 10, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		line any
+		want string
+	}{
+		{
+			name: "header",
+			line: Header{Type: TypeHeader, Format: Format, Version: Version, ExportedAt: at},
+			want: `{"type":"header","format":"grpcchat.chat","version":1,"exported_at":"2025-10-01T12:30:00Z"}`,
+		},
+		{
+			name: "user with email",
+			line: User{Type: TypeUser, ID: 7, Email: "ann@example.com", Name: "Ann"},
+			want: `{"type":"user","id":7,"email":"ann@example.com","name":"Ann"}`,
+		},
+		{
+			name: "user without email",
+			line: User{Type: TypeUser, ID: 7, Name: "Ann"},
+			want: `{"type":"user","id":7,"name":"Ann"}`,
+		},
+		{
+			name: "text is not html escaped",
+			line: Message{Type: TypeMessage, ID: 1, Seq: 1, SenderID: 7, Text: "<b> & </b>", CreatedAt: at},
+			want: `{"type":"message","id":1,"seq":1,"sender_id":7,"text":"<b> & </b>","created_at":"2025-10-01T12:30:00Z"}`,
+		},
+		{
+			name: "optional message fields",
+			line: Message{
+				Type:             TypeMessage,
+				ID:               2,
+				Seq:              2,
+				SenderID:         7,
+				Text:             "",
+				CreatedAt:        at,
+				DeletedAt:        &at,
+				ReplyToID:        1,
+				MentionedUserIDs: []int64{8},
+			},
+			want: `{"type":"message","id":2,"seq":2,"sender_id":7,"text":"","created_at":"2025-10-01T12:30:00Z",` +
+				`"deleted_at":"2025-10-01T12:30:00Z","reply_to_id":1,"mentioned_user_ids":[8]}`,
+		},
+		{
+			name: "newline in text stays on one line",
+			line: Message{Type: TypeMessage, ID: 3, Seq: 3, SenderID: 7, Text: "a\nb", CreatedAt: at},
+			want: `{"type":"message","id":3,"seq":3,"sender_id":7,"text":"a\nb","created_at":"2025-10-01T12:30:00Z"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewEncoder(&buf).Encode(tt.line); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want+"\n" {
+				t.Errorf("Encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	at := time.Date(2025, 10, 1, 12, 30, 0, 0, time.UTC)
+	edited := at.Add(time.Minute)
+
+	lines := []any{
+		Header{Type: TypeHeader, Format: Format, Version: Version, ExportedAt: at},
+		Chat{Type: TypeChat, ID: 1, Name: "team", Kind: "group", Retention: Retention{Mode: "days", TTLSeconds: 86400}, CreatedAt: at},
+		User{Type: TypeUser, ID: 7, Email: "ann@example.com", Name: "Ann"},
+		Member{Type: TypeMember, UserID: 7, Role: "owner", JoinedAt: at},
+		Blob{Type: TypeBlob, StorageKey: "k1", Data: []byte{0, 1, 2, 0xff, '\n', '"'}},
+		Message{
+			Type:             TypeMessage,
+			ID:               10,
+			Seq:              1,
+			SenderID:         7,
+			Text:             "hi @ann",
+			CreatedAt:        at,
+			EditedAt:         &edited,
+			MentionedUserIDs: []int64{7},
+			Reactions:        []Reaction{{UserID: 7, Emoji: "👍", CreatedAt: at}},
+			Attachments: []Attachment{{
+				ID:          3,
+				UploaderID:  7,
+				Filename:    "a.png",
+				ContentType: "image/png",
+				Size:        6,
+				Width:       2,
+				Height:      1,
+				StorageKey:  "k1",
+				CreatedAt:   at,
+			}},
+		},
+		Pin{Type: TypePin, MessageID: 10, PinnedBy: 7, PinnedAt: at},
+		End{Type: TypeEnd, Users: 1, Members: 1, Blobs: 1, Messages: 1, Pins: 1},
+	}
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	for _, line := range lines {
+		if err := enc.Encode(line); err != nil {
+			t.Fatalf("Encode(%T) error = %v", line, err)
+		}
+	}
+
+	dec := NewDecoder(&buf)
+	for _, want := range lines {
+		typ, err := dec.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v, want a %T line", err, want)
+		}
+
+		got := reflect.New(reflect.TypeOf(want))
+		if err := dec.Decode(got.Interface()); err != nil {
+			t.Fatalf("Decode(%T) error = %v", want, err)
+		}
+		if !reflect.DeepEqual(got.Elem().Interface(), want) {
+			t.Errorf("%s line = %+v, want %+v", typ, got.Elem().Interface(), want)
+		}
+	}
+
+	if typ, err := dec.Next(); err != io.EOF {
+		t.Errorf("Next() at the end = %q, %v, want io.EOF", typ, err)
+	}
+}
+
+func TestDecoderNext(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantTypes []string
+		wantErr   bool
+	}{
+		{name: "empty", input: ""},
+		{name: "blank lines are skipped", input: "\n  \n{\"type\":\"pin\"}\n\n{\"type\":\"end\"}", wantTypes: []string{"pin", "end"}},
+		{name: "unknown type", input: `{"type":"poll","question":"?"}`, wantTypes: []string{"poll"}},
+		{name: "no type", input: `{"id":1}`, wantErr: true},
+		{name: "not json", input: "type: header", wantErr: true},
+		{name: "line too long", input: `{"type":"blob","data":"` + strings.Repeat("A", maxLineSize) + `"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(tt.input))
+
+			var types []string
+			var err error
+			for {
+				var typ string
+				if typ, err = dec.Next(); err != nil {
+					break
+				}
+				types = append(types, typ)
+			}
+
+			if !reflect.DeepEqual(types, tt.wantTypes) {
+				t.Errorf("types = %q, want %q", types, tt.wantTypes)
+			}
+			if tt.wantErr == errors.Is(err, io.EOF) {
+				t.Errorf("Next() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package imaging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+const (
+	markerSOI  = 0xD8
+	markerSOS  = 0xDA
+	markerAPP1 = 0xE1
+
+	tagOrientation = 0x0112
+	typeShort      = 3
+)
+
+// StripEXIF copies a JPEG stream from src to dst without its EXIF segments,
+// leaving the image data untouched. A rotated or mirrored image keeps its
+// orientation in a minimal EXIF segment of its own, so that it is still shown
+// upright. Anything that is not a JPEG is copied as is.
+func StripEXIF(dst io.Writer, src io.Reader) error {
+	op := "lib.imaging.StripEXIF"
+
+	err := walkJPEG(dst, src, func(payload []byte) error {
+		if o := exifOrientation(payload); o != 1 {
+			if _, err := dst.Write(orientationSegment(o)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Orientation returns the EXIF orientation of a JPEG stream, from 1 to 8, or
+// 1 when there is none or r is not a JPEG.
+func Orientation(r io.Reader) (int, error) {
+	op := "lib.imaging.Orientation"
+
+	orientation := 1
+	err := walkJPEG(io.Discard, r, func(payload []byte) error {
+		orientation = exifOrientation(payload)
+		return errStopWalk
+	})
+	if err != nil && !errors.Is(err, errStopWalk) {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orientation, nil
+}
+
+var errStopWalk = errors.New("stop walking")
+
+// walkJPEG copies src to dst segment by segment, handing the payload of each
+// EXIF segment to exif instead of copying it.
+func walkJPEG(dst io.Writer, src io.Reader, exif func(payload []byte) error) error {
+	r := bufio.NewReader(src)
+
+	soi, err := r.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if len(soi) < 2 || soi[0] != 0xFF || soi[1] != markerSOI {
+		_, err := io.Copy(dst, r)
+		return err
+	}
+
+	if _, err := io.CopyN(dst, r, 2); err != nil {
+		return err
+	}
+
+	for {
+		marker, err := r.Peek(2)
+		if err != nil || marker[0] != 0xFF {
+			// Not a segment boundary: leave the rest to the decoder.
+			break
+		}
+
+		// Segments without a length, and everything from the start of scan on,
+		// are passed through unchanged.
+		code := marker[1]
+		if code == markerSOS || code == 0x01 || (code >= 0xD0 && code <= 0xD9) {
+			break
+		}
+
+		head, err := r.Peek(4)
+		if err != nil {
+			break
+		}
+		length := int64(binary.BigEndian.Uint16(head[2:]))
+		if length < 2 {
+			break
+		}
+
+		if code == markerAPP1 {
+			prefix, _ := r.Peek(4 + len(exifHeader))
+			if len(prefix) == 4+len(exifHeader) && bytes.Equal(prefix[4:], exifHeader) {
+				segment := make([]byte, 2+length)
+				if _, err := io.ReadFull(r, segment); err != nil {
+					return err
+				}
+				if err := exif(segment[4:]); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if _, err := io.CopyN(dst, r, 2+length); err != nil {
+			return err
+		}
+	}
+
+	_, err = io.Copy(dst, r)
+	return err
+}
+
+// exifOrientation reads the orientation tag from the first IFD of an EXIF
+// payload. Missing, malformed and out of range values count as 1.
+func exifOrientation(payload []byte) int {
+	tiff := payload[len(exifHeader):]
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := range count {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) != tagOrientation {
+			continue
+		}
+		if order.Uint16(tiff[entry+2:]) != typeShort {
+			return 1
+		}
+
+		o := int(order.Uint16(tiff[entry+8:]))
+		if o < 1 || o > 8 {
+			return 1
+		}
+		return o
+	}
+
+	return 1
+}
+
+// orientationSegment builds an APP1 segment with an EXIF block that holds
+// nothing but the orientation.
+func orientationSegment(orientation int) []byte {
+	var b bytes.Buffer
+	b.Write([]byte{0xFF, markerAPP1, 0, 0})
+	b.Write(exifHeader)
+	// Big endian TIFF header with the first IFD right after it.
+	b.Write([]byte{'M', 'M', 0, 42, 0, 0, 0, 8})
+	// One entry: orientation, SHORT, count 1, value padded to four bytes.
+	b.Write([]byte{0, 1})
+	b.Write([]byte{tagOrientation >> 8, tagOrientation & 0xFF, 0, typeShort, 0, 0, 0, 1, 0, byte(orientation), 0, 0})
+	// No next IFD.
+	b.Write([]byte{0, 0, 0, 0})
+
+	segment := b.Bytes()
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(segment)-2))
+	return segment
+}
@@ -0,0 +1,189 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"testing"
+)
+
+// segment builds a JPEG marker segment with a length field.
+func segment(code byte, payload []byte) []byte {
+	s := []byte{0xFF, code, 0, 0}
+	binary.BigEndian.PutUint16(s[2:], uint16(len(payload)+2))
+	return append(s, payload...)
+}
+
+// exifPayload builds an EXIF APP1 payload with a first IFD holding a made up
+// camera tag and, unless orientation is zero, the orientation.
+func exifPayload(order binary.ByteOrder, orientation int) []byte {
+	type entry struct {
+		tag, typ uint16
+		value    uint16
+	}
+	entries := []entry{{tag: 0x010F, typ: 2, value: 0}}
+	if orientation != 0 {
+		entries = append(entries, entry{tag: tagOrientation, typ: typeShort, value: uint16(orientation)})
+	}
+
+	tiff := make([]byte, 8+2+12*len(entries)+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], uint16(len(entries)))
+	for i, e := range entries {
+		p := tiff[10+12*i:]
+		order.PutUint16(p, e.tag)
+		order.PutUint16(p[2:], e.typ)
+		order.PutUint32(p[4:], 1)
+		order.PutUint16(p[8:], e.value)
+	}
+
+	return append(append([]byte{}, exifHeader...), tiff...)
+}
+
+func jpegOf(segments ...[]byte) []byte {
+	b := []byte{0xFF, markerSOI}
+	for _, s := range segments {
+		b = append(b, s...)
+	}
+	return b
+}
+
+func TestStripEXIF(t *testing.T) {
+	app0 := segment(0xE0, []byte("JFIF\x00\x01\x02"))
+	xmp := segment(markerAPP1, []byte("http://ns.adobe.com/xap/1.0/\x00<x/>"))
+	// scan is the start of scan and entropy coded data, which may contain
+	// anything, through the end of image.
+	scan := append(segment(markerSOS, []byte{1, 2, 3}), 0xFF, 0xE1, 0x00, 0x10, 'E', 'x', 'i', 'f', 0xFF, 0xD9)
+
+	tests := []struct {
+		name string
+		in   []byte
+		want []byte
+	}{
+		{
+			name: "not a jpeg",
+			in:   []byte("\x89PNG\r\n\x1a\n"),
+			want: []byte("\x89PNG\r\n\x1a\n"),
+		},
+		{
+			name: "empty",
+			in:   []byte{},
+			want: []byte{},
+		},
+		{
+			name: "no exif",
+			in:   jpegOf(app0, scan),
+			want: jpegOf(app0, scan),
+		},
+		{
+			name: "exif without orientation",
+			in:   jpegOf(app0, segment(markerAPP1, exifPayload(binary.BigEndian, 0)), scan),
+			want: jpegOf(app0, scan),
+		},
+		{
+			name: "upright exif",
+			in:   jpegOf(segment(markerAPP1, exifPayload(binary.LittleEndian, 1)), app0, scan),
+			want: jpegOf(app0, scan),
+		},
+		{
+			name: "rotated little endian",
+			in:   jpegOf(segment(markerAPP1, exifPayload(binary.LittleEndian, 6)), app0, scan),
+			want: jpegOf(orientationSegment(6), app0, scan),
+		},
+		{
+			name: "mirrored big endian",
+			in:   jpegOf(app0, segment(markerAPP1, exifPayload(binary.BigEndian, 2)), scan),
+			want: jpegOf(app0, orientationSegment(2), scan),
+		},
+		{
+			name: "out of range orientation",
+			in:   jpegOf(segment(markerAPP1, exifPayload(binary.BigEndian, 9)), scan),
+			want: jpegOf(scan),
+		},
+		{
+			name: "other app1 segments are kept",
+			in:   jpegOf(xmp, segment(markerAPP1, exifPayload(binary.BigEndian, 0)), scan),
+			want: jpegOf(xmp, scan),
+		},
+		{
+			name: "truncated segment is passed through",
+			in:   jpegOf(app0, []byte{0xFF, 0xE0, 0x00}),
+			want: jpegOf(app0, []byte{0xFF, 0xE0, 0x00}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := StripEXIF(&out, bytes.NewReader(tt.in)); err != nil {
+				t.Fatalf("StripEXIF() error = %v", err)
+			}
+			if !bytes.Equal(out.Bytes(), tt.want) {
+				t.Errorf("StripEXIF() = %x, want %x", out.Bytes(), tt.want)
+			}
+		})
+	}
+}
+
+func TestOrientation(t *testing.T) {
+	scan := append(segment(markerSOS, []byte{1, 2, 3}), 0xFF, 0xD9)
+
+	tests := []struct {
+		name string
+		in   []byte
+		want int
+	}{
+		{name: "not a jpeg", in: []byte("GIF89a"), want: 1},
+		{name: "no exif", in: jpegOf(scan), want: 1},
+		{name: "no orientation tag", in: jpegOf(segment(markerAPP1, exifPayload(binary.LittleEndian, 0)), scan), want: 1},
+		{name: "little endian", in: jpegOf(segment(markerAPP1, exifPayload(binary.LittleEndian, 8)), scan), want: 8},
+		{name: "big endian", in: jpegOf(segment(markerAPP1, exifPayload(binary.BigEndian, 3)), scan), want: 3},
+		{name: "invalid value", in: jpegOf(segment(markerAPP1, exifPayload(binary.BigEndian, 0x0100)), scan), want: 1},
+		{name: "kept by StripEXIF", in: jpegOf(orientationSegment(5), scan), want: 5},
+		{name: "broken tiff header", in: jpegOf(segment(markerAPP1, append(append([]byte{}, exifHeader...), "XX"...)), scan), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Orientation(bytes.NewReader(tt.in))
+			if err != nil {
+				t.Fatalf("Orientation() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Orientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripEXIFKeepsImageDecodable(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, image.NewGray(image.Rect(0, 0, 8, 4)), nil); err != nil {
+		t.Fatal(err)
+	}
+	in := jpegOf(segment(markerAPP1, exifPayload(binary.LittleEndian, 6)), encoded.Bytes()[2:])
+
+	var out bytes.Buffer
+	if err := StripEXIF(&out, bytes.NewReader(in)); err != nil {
+		t.Fatalf("StripEXIF() error = %v", err)
+	}
+
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		t.Fatalf("decoding the stripped image: %v", err)
+	}
+	if cfg.Width != 8 || cfg.Height != 4 {
+		t.Errorf("size = %dx%d, want 8x4", cfg.Width, cfg.Height)
+	}
+
+	if o, err := Orientation(bytes.NewReader(out.Bytes())); err != nil || o != 6 {
+		t.Errorf("Orientation() = %d, %v, want 6", o, err)
+	}
+}
@@ -0,0 +1,178 @@
+// Package imaging decodes uploaded images and renders their thumbnails with
+// the standard library codecs only.
+package imaging
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+var ErrTooLarge = errors.New("image dimensions exceed the limit")
+
+const jpegQuality = 85
+
+// DecodeConfig reads the format and dimensions from the image header.
+func DecodeConfig(r io.Reader) (image.Config, string, error) {
+	op := "lib.imaging.DecodeConfig"
+
+	cfg, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return image.Config{}, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return cfg, format, nil
+}
+
+// Decode decodes a PNG, JPEG or GIF image, the first frame for animated GIFs.
+// Images with more than maxPixels pixels are rejected before decoding.
+func Decode(r io.Reader, cfg image.Config, maxPixels int) (image.Image, error) {
+	op := "lib.imaging.Decode"
+
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("%s: %w", op, ErrTooLarge)
+	}
+
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return img, nil
+}
+
+// Fit scales width and height down to fit within a box x box square while
+// keeping the aspect ratio. Images that already fit are left as they are.
+func Fit(width, height, box int) (int, int) {
+	if width <= box && height <= box {
+		return width, height
+	}
+
+	if width >= height {
+		return box, max(1, height*box/width)
+	}
+	return max(1, width*box/height), box
+}
+
+// ToRGBA returns src as an RGBA image anchored at the origin, converting it if
+// it is not one already. Convert once and resize the result as often as
+// needed.
+func ToRGBA(src image.Image) *image.RGBA {
+	b := src.Bounds()
+
+	if rgba, ok := src.(*image.RGBA); ok && b.Min == (image.Point{}) {
+		return rgba
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	return rgba
+}
+
+// Resize scales src to width x height by averaging the source pixels that
+// fall into each destination pixel. It is meant for downscaling. src must be
+// anchored at the origin, as ToRGBA returns it.
+func Resize(src *image.RGBA, width, height int) *image.RGBA {
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		y0 := y * srcH / height
+		y1 := max(y0+1, (y+1)*srcH/height)
+
+		for x := range width {
+			x0 := x * srcW / width
+			x1 := max(x0+1, (x+1)*srcW/width)
+
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					bl += uint32(p[2])
+					a += uint32(p[3])
+					n++
+				}
+			}
+
+			d := dst.Pix[y*dst.Stride+x*4 : y*dst.Stride+x*4+4]
+			d[0] = uint8(r / n)
+			d[1] = uint8(g / n)
+			d[2] = uint8(bl / n)
+			d[3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// Orient turns an image stored with the given EXIF orientation upright. The
+// result is a new image except for orientation 1, which returns src as is.
+func Orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := range dh {
+		for x := range dw {
+			// (sx, sy) is the stored pixel shown at (x, y).
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+
+			s := src.PixOffset(sx, sy)
+			d := dst.PixOffset(x, y)
+			copy(dst.Pix[d:d+4], src.Pix[s:s+4])
+		}
+	}
+
+	return dst
+}
+
+// Encode writes img as JPEG when the source was a JPEG and as PNG otherwise,
+// so transparency survives, and returns the content type written. Encoding
+// from pixels carries over no metadata.
+func Encode(w io.Writer, img image.Image, format string) (string, error) {
+	op := "lib.imaging.Encode"
+
+	if format == "jpeg" {
+		if err := jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		return "image/jpeg", nil
+	}
+
+	if err := png.Encode(w, img); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return "image/png", nil
+}
@@ -0,0 +1,153 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		box           int
+		wantW, wantH  int
+	}{
+		{name: "already fits", width: 100, height: 50, box: 160, wantW: 100, wantH: 50},
+		{name: "exactly the box", width: 160, height: 160, box: 160, wantW: 160, wantH: 160},
+		{name: "landscape", width: 1600, height: 900, box: 160, wantW: 160, wantH: 90},
+		{name: "portrait", width: 900, height: 1600, box: 160, wantW: 90, wantH: 160},
+		{name: "square", width: 4000, height: 4000, box: 640, wantW: 640, wantH: 640},
+		{name: "thin strip keeps one pixel", width: 10000, height: 1, box: 160, wantW: 160, wantH: 1},
+		{name: "tall strip keeps one pixel", width: 1, height: 10000, box: 160, wantW: 1, wantH: 160},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h := Fit(tt.width, tt.height, tt.box)
+			if w != tt.wantW || h != tt.wantH {
+				t.Errorf("Fit(%d, %d, %d) = %dx%d, want %dx%d", tt.width, tt.height, tt.box, w, h, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	red := color.RGBA{R: 200, A: 255}
+	blue := color.RGBA{B: 100, A: 255}
+
+	// quadrants is 4x4 with a red left half and a blue right half.
+	quadrants := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := range 4 {
+		for x := range 4 {
+			if x < 2 {
+				quadrants.SetRGBA(x, y, red)
+			} else {
+				quadrants.SetRGBA(x, y, blue)
+			}
+		}
+	}
+
+	tests := []struct {
+		name          string
+		width, height int
+		want          [][]color.RGBA
+	}{
+		{
+			name:  "halves keep their colors",
+			width: 2, height: 2,
+			want: [][]color.RGBA{{red, blue}, {red, blue}},
+		},
+		{
+			name:  "single pixel averages everything",
+			width: 1, height: 1,
+			want: [][]color.RGBA{{{R: 100, B: 50, A: 255}}},
+		},
+		{
+			name:  "same size copies",
+			width: 4, height: 1,
+			want: [][]color.RGBA{{red, red, blue, blue}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Resize(quadrants, tt.width, tt.height)
+			if b := got.Bounds(); b.Dx() != tt.width || b.Dy() != tt.height {
+				t.Fatalf("size = %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.width, tt.height)
+			}
+			for y, row := range tt.want {
+				for x, want := range row {
+					if c := got.RGBAAt(x, y); c != want {
+						t.Errorf("pixel (%d, %d) = %v, want %v", x, y, c, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestToRGBA(t *testing.T) {
+	gray := image.NewGray(image.Rect(5, 5, 8, 7))
+	gray.SetGray(5, 5, color.Gray{Y: 42})
+
+	got := ToRGBA(gray)
+	if got.Bounds() != image.Rect(0, 0, 3, 2) {
+		t.Fatalf("bounds = %v, want anchored 3x2", got.Bounds())
+	}
+	if c := got.RGBAAt(0, 0); c != (color.RGBA{R: 42, G: 42, B: 42, A: 255}) {
+		t.Errorf("pixel (0, 0) = %v, want the top left source pixel", c)
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	if ToRGBA(rgba) != rgba {
+		t.Error("an anchored RGBA image was copied")
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// The source is 3x2 and every pixel carries a letter in its red channel:
+	//
+	//	a b c
+	//	d e f
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	for i, letter := range "abcdef" {
+		src.SetRGBA(i%3, i/3, color.RGBA{R: uint8(letter), A: 255})
+	}
+
+	tests := []struct {
+		orientation int
+		want        []string
+	}{
+		{orientation: 1, want: []string{"abc", "def"}},
+		{orientation: 2, want: []string{"cba", "fed"}},
+		{orientation: 3, want: []string{"fed", "cba"}},
+		{orientation: 4, want: []string{"def", "abc"}},
+		{orientation: 5, want: []string{"ad", "be", "cf"}},
+		{orientation: 6, want: []string{"da", "eb", "fc"}},
+		{orientation: 7, want: []string{"fc", "eb", "da"}},
+		{orientation: 8, want: []string{"cf", "be", "ad"}},
+		{orientation: 0, want: []string{"abc", "def"}},
+		{orientation: 9, want: []string{"abc", "def"}},
+	}
+
+	for _, tt := range tests {
+		got := Orient(src, tt.orientation)
+
+		b := got.Bounds()
+		if b.Dx() != len(tt.want[0]) || b.Dy() != len(tt.want) {
+			t.Errorf("orientation %d: size = %dx%d, want %dx%d", tt.orientation, b.Dx(), b.Dy(), len(tt.want[0]), len(tt.want))
+			continue
+		}
+
+		for y, row := range tt.want {
+			var line []byte
+			for x := range len(row) {
+				line = append(line, got.RGBAAt(x, y).R)
+			}
+			if string(line) != row {
+				t.Errorf("orientation %d: row %d = %q, want %q", tt.orientation, y, line, row)
+			}
+		}
+	}
+}
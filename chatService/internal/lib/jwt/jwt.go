@@ -0,0 +1,38 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// ParseToken validates an HS256 access token issued by authService and
+// returns the id of the user it was issued for.
+func ParseToken(tokenString string, secret string) (int64, error) {
+	op := "lib.jwt.ParseToken"
+
+	token, err := jwt.Parse(
+		tokenString,
+		func(*jwt.Token) (any, error) { return []byte(secret), nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w: %w", op, ErrInvalidToken, err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+	}
+
+	id, ok := claims["id"].(float64)
+	if !ok || id <= 0 {
+		return 0, fmt.Errorf("%s: %w: missing user id", op, ErrInvalidToken)
+	}
+
+	return int64(id), nil
+}
@@ -0,0 +1,254 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// attachmentColumns is the column list scanAttachment expects, in order.
+const attachmentColumns = `
+	id, chat_id, COALESCE(message_id, 0), uploader_id, filename, content_type,
+	size, storage_key, created_at, width, height`
+
+// thumbnailColumns is the column list scanThumbnail expects, in order.
+const thumbnailColumns = `
+	id, attachment_id, width, height, content_type, size, storage_key`
+
+type AttachmentStorage struct {
+	db *pgxpool.Pool
+}
+
+func NewAttachmentRepository(ctx context.Context, dbCfg *config.DBConfig) (*AttachmentStorage, error) {
+	dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
+		dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Port, dbCfg.DBName)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &AttachmentStorage{db: pool}, nil
+}
+
+// Create records an uploaded blob together with its thumbnails. The
+// attachment stays unsent until a message references it.
+func (s *AttachmentStorage) Create(ctx context.Context, att models.Attachment) (models.Attachment, error) {
+	op := "repo.Attachment.Create"
+
+	query := `
+		INSERT INTO attachments (chat_id, uploader_id, filename, content_type, size, storage_key, width, height)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + attachmentColumns
+
+	thumbQuery := `
+		INSERT INTO attachment_thumbnails (attachment_id, width, height, content_type, size, storage_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + thumbnailColumns
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	res, err := scanAttachment(tx.QueryRow(ctx, query,
+		att.ChatID, att.UploaderID, att.Filename, att.ContentType, att.Size, att.StorageKey, att.Width, att.Height))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return models.Attachment{}, fmt.Errorf("%s: %w", op, ErrChatNotFound)
+		}
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, thumb := range att.Thumbnails {
+		thumb, err := scanThumbnail(tx.QueryRow(ctx, thumbQuery,
+			res.ID, thumb.Width, thumb.Height, thumb.ContentType, thumb.Size, thumb.StorageKey))
+		if err != nil {
+			return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+		}
+		res.Thumbnails = append(res.Thumbnails, thumb)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// Get returns an attachment with its thumbnails.
+func (s *AttachmentStorage) Get(ctx context.Context, id int64) (models.Attachment, error) {
+	op := "repo.Attachment.Get"
+
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments
+		WHERE id = $1
+	`
+
+	att, err := scanAttachment(s.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Attachment{}, fmt.Errorf("%s: %w", op, ErrAttachmentNotFound)
+		}
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	thumbs, err := s.thumbnails(ctx, []int64{att.ID})
+	if err != nil {
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+	att.Thumbnails = thumbs[att.ID]
+
+	return att, nil
+}
+
+// GetThumbnail returns a thumbnail by id. Its attachment is looked up
+// separately with Get.
+func (s *AttachmentStorage) GetThumbnail(ctx context.Context, id int64) (models.Thumbnail, error) {
+	op := "repo.Attachment.GetThumbnail"
+
+	query := `
+		SELECT ` + thumbnailColumns + `
+		FROM attachment_thumbnails
+		WHERE id = $1
+	`
+
+	thumb, err := scanThumbnail(s.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Thumbnail{}, fmt.Errorf("%s: %w", op, ErrAttachmentNotFound)
+		}
+		return models.Thumbnail{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return thumb, nil
+}
+
+// ListByMessages returns the attachments of the given messages with their
+// thumbnails, keyed by message id, each in upload order.
+func (s *AttachmentStorage) ListByMessages(ctx context.Context, messageIDs []int64) (map[int64][]models.Attachment, error) {
+	op := "repo.Attachment.ListByMessages"
+
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments
+		WHERE message_id = ANY($1)
+		ORDER BY message_id, id
+	`
+
+	rows, err := s.db.Query(ctx, query, messageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		list []models.Attachment
+		ids  []int64
+	)
+	for rows.Next() {
+		att, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		list = append(list, att)
+		ids = append(ids, att.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	thumbs, err := s.thumbnails(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	atts := make(map[int64][]models.Attachment)
+	for _, att := range list {
+		att.Thumbnails = thumbs[att.ID]
+		atts[att.MessageID] = append(atts[att.MessageID], att)
+	}
+
+	return atts, nil
+}
+
+// thumbnails returns the thumbnails of the given attachments keyed by
+// attachment id, smallest first.
+func (s *AttachmentStorage) thumbnails(ctx context.Context, attachmentIDs []int64) (map[int64][]models.Thumbnail, error) {
+	if len(attachmentIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT ` + thumbnailColumns + `
+		FROM attachment_thumbnails
+		WHERE attachment_id = ANY($1)
+		ORDER BY attachment_id, width
+	`
+
+	rows, err := s.db.Query(ctx, query, attachmentIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	thumbs := make(map[int64][]models.Thumbnail)
+	for rows.Next() {
+		thumb, err := scanThumbnail(rows)
+		if err != nil {
+			return nil, err
+		}
+		thumbs[thumb.AttachmentID] = append(thumbs[thumb.AttachmentID], thumb)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return thumbs, nil
+}
+
+func scanAttachment(row pgx.Row) (models.Attachment, error) {
+	var att models.Attachment
+	err := row.Scan(
+		&att.ID,
+		&att.ChatID,
+		&att.MessageID,
+		&att.UploaderID,
+		&att.Filename,
+		&att.ContentType,
+		&att.Size,
+		&att.StorageKey,
+		&att.CreatedAt,
+		&att.Width,
+		&att.Height,
+	)
+	return att, err
+}
+
+func scanThumbnail(row pgx.Row) (models.Thumbnail, error) {
+	var thumb models.Thumbnail
+	err := row.Scan(
+		&thumb.ID,
+		&thumb.AttachmentID,
+		&thumb.Width,
+		&thumb.Height,
+		&thumb.ContentType,
+		&thumb.Size,
+		&thumb.StorageKey,
+	)
+	return thumb, err
+}
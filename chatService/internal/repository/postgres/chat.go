@@ -1 +1,258 @@
 package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ChatStorage struct {
+	db *pgxpool.Pool
+}
+
+func NewChatRepository(ctx context.Context, dbCfg *config.DBConfig) (*ChatStorage, error) {
+	dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
+		dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Port, dbCfg.DBName)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &ChatStorage{db: pool}, nil
+}
+
+// Create stores a chat together with its creator as the owner.
+func (s *ChatStorage) Create(ctx context.Context, name string, public bool, creatorID int64) (models.Chat, error) {
+	op := "repo.Chat.Create"
+
+	query := `
+		WITH chat AS (
+			INSERT INTO chats (name, kind, public)
+			VALUES ($1, 'group', $3)
+			RETURNING id, name
+		), member AS (
+			INSERT INTO chat_members (chat_id, user_id, role)
+			SELECT id, $2, 'owner'
+			FROM chat
+		)
+		SELECT id, name
+		FROM chat
+	`
+
+	chat := models.Chat{
+		Kind:      models.ChatKindGroup,
+		Public:    public,
+		Retention: models.Retention{Mode: models.RetentionForever},
+	}
+	err := s.db.QueryRow(ctx, query, name, creatorID, public).Scan(&chat.ID, &chat.Name)
+	if err != nil {
+		return models.Chat{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return chat, nil
+}
+
+// GetOrCreateDirect returns the direct chat between two users, named after
+// the peer, creating it with both of them as members if it does not exist yet. Concurrent calls for
+// the same pair resolve to a single chat through idx_chats_direct_users.
+func (s *ChatStorage) GetOrCreateDirect(ctx context.Context, userID, peerID int64) (models.Chat, error) {
+	op := "repo.Chat.GetOrCreateDirect"
+
+	low, high := min(userID, peerID), max(userID, peerID)
+
+	insertQuery := `
+		WITH chat AS (
+			INSERT INTO chats (name, kind, direct_user_low, direct_user_high)
+			SELECT '', 'direct', $1, $2
+			WHERE EXISTS (SELECT 1 FROM users WHERE id = $3)
+			ON CONFLICT (direct_user_low, direct_user_high) WHERE kind = 'direct' DO NOTHING
+			RETURNING id
+		), members AS (
+			INSERT INTO chat_members (chat_id, user_id, role)
+			SELECT chat.id, u.id, 'member'
+			FROM chat, unnest(ARRAY[$1::bigint, $2::bigint]) AS u(id)
+		)
+		SELECT id, (SELECT name FROM users WHERE id = $3)
+		FROM chat
+	`
+
+	chat := models.Chat{
+		Kind:      models.ChatKindDirect,
+		PeerID:    peerID,
+		Retention: models.Retention{Mode: models.RetentionForever},
+	}
+	err := s.db.QueryRow(ctx, insertQuery, low, high, peerID).Scan(&chat.ID, &chat.Name)
+	if err == nil {
+		return chat, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return models.Chat{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Either the chat already exists or the peer does not. This runs as a
+	// separate statement so it sees a chat committed by a concurrent call.
+	selectQuery := `
+		SELECT id, COALESCE((SELECT name FROM users WHERE id = $3), ''), retention_mode, retention_ttl
+		FROM chats
+		WHERE kind = 'direct' AND direct_user_low = $1 AND direct_user_high = $2
+	`
+	var (
+		retention string
+		ttl       int64
+	)
+	err = s.db.QueryRow(ctx, selectQuery, low, high, peerID).Scan(&chat.ID, &chat.Name, &retention, &ttl)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Chat{}, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		return models.Chat{}, fmt.Errorf("%s: %w", op, err)
+	}
+	chat.Retention = retentionFromRow(retention, ttl)
+
+	return chat, nil
+}
+
+func (s *ChatStorage) Get(ctx context.Context, id int64) (models.Chat, error) {
+	op := "repo.Chat.Get"
+
+	query := `
+		SELECT id, name, kind, public, retention_mode, retention_ttl
+		FROM chats
+		WHERE id = $1
+	`
+
+	var (
+		chat      models.Chat
+		kind      string
+		retention string
+		ttl       int64
+	)
+	err := s.db.QueryRow(ctx, query, id).Scan(&chat.ID, &chat.Name, &kind, &chat.Public, &retention, &ttl)
+	chat.Kind = models.ChatKind(kind)
+	chat.Retention = retentionFromRow(retention, ttl)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Chat{}, fmt.Errorf("%s: %w", op, ErrChatNotFound)
+		}
+		return models.Chat{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return chat, nil
+}
+
+// GetList returns the chats the user is a member of. Direct chats are named
+// after the other participant. Unread counts come from sequence numbers, so
+// they cost nothing extra however large a chat is; messages below the first
+// one retention left alive are not counted, and neither are the tombstones
+// past the read cursor, which a partial index keeps cheap to find.
+func (s *ChatStorage) GetList(ctx context.Context, userID int64) ([]models.Chat, error) {
+	op := "repo.Chat.GetList"
+
+	query := `
+		SELECT
+			c.id,
+			CASE WHEN c.kind = 'direct' THEN COALESCE(u.name, '') ELSE c.name END,
+			c.kind,
+			c.public,
+			COALESCE(peer.user_id, 0),
+			c.last_seq - r.seq - (
+				SELECT count(*)
+				FROM messages d
+				WHERE d.chat_id = c.id AND d.seq > r.seq AND d.deleted_at IS NOT NULL
+			),
+			c.retention_mode,
+			c.retention_ttl
+		FROM chats c
+		JOIN chat_members m ON m.chat_id = c.id
+		LEFT JOIN chat_members peer
+			ON c.kind = 'direct' AND peer.chat_id = c.id AND peer.user_id <> m.user_id
+		LEFT JOIN users u ON u.id = peer.user_id
+		LEFT JOIN chat_live_seqs l ON l.chat_id = c.id
+		CROSS JOIN LATERAL (
+			SELECT GREATEST(m.last_read_seq, COALESCE(l.min_live_seq, 1) - 1) AS seq
+		) r
+		WHERE m.user_id = $1
+		ORDER BY c.id
+	`
+
+	rows, err := s.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var chats []models.Chat
+	for rows.Next() {
+		var (
+			chat      models.Chat
+			kind      string
+			retention string
+			ttl       int64
+		)
+		if err := rows.Scan(&chat.ID, &chat.Name, &kind, &chat.Public, &chat.PeerID, &chat.UnreadCount, &retention, &ttl); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		chat.Kind = models.ChatKind(kind)
+		chat.Retention = retentionFromRow(retention, ttl)
+		chats = append(chats, chat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return chats, nil
+}
+
+func (s *ChatStorage) SetRetention(ctx context.Context, id int64, retention models.Retention) error {
+	op := "repo.Chat.SetRetention"
+
+	query := `
+		UPDATE chats
+		SET retention_mode = $2, retention_ttl = $3
+		WHERE id = $1
+	`
+	tag, err := s.db.Exec(ctx, query, id, string(retention.Mode), int64(retention.TTL/time.Second))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrChatNotFound)
+	}
+
+	return nil
+}
+
+func (s *ChatStorage) Delete(ctx context.Context, id int64) error {
+	op := "repo.Chat.Delete"
+
+	query := `
+		DELETE FROM chats
+		WHERE id = $1
+	`
+	_, err := s.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// retentionFromRow builds a Retention from the retention_mode and
+// retention_ttl columns; the TTL is stored in seconds.
+func retentionFromRow(mode string, ttl int64) models.Retention {
+	return models.Retention{
+		Mode: models.RetentionMode(mode),
+		TTL:  time.Duration(ttl) * time.Second,
+	}
+}
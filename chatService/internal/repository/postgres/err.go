@@ -0,0 +1,24 @@
+package postgres
+
+import "errors"
+
+var (
+	ErrChatNotFound     = errors.New("chat not found")
+	ErrMessageNotFound  = errors.New("message not found")
+	ErrMessageDeleted   = errors.New("message deleted")
+	ErrMemberExists     = errors.New("member already exists")
+	ErrMemberNotFound   = errors.New("member not found")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrReactionExists   = errors.New("reaction already exists")
+	ErrReactionNotFound = errors.New("reaction not found")
+	// ErrAttachmentNotFound is also returned for attachments that cannot be
+	// sent: already sent, uploaded by someone else or to another chat.
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrMessagePinned        = errors.New("message already pinned")
+	ErrPinNotFound          = errors.New("message is not pinned")
+	ErrPinLimitReached      = errors.New("pin limit reached")
+	ErrDirectChatExists     = errors.New("direct chat already exists")
+	ErrImportConflict       = errors.New("import is running concurrently")
+	ErrHandleTaken          = errors.New("handle is taken")
+)
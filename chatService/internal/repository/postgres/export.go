@@ -0,0 +1,324 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExportStorage struct {
+	db *pgxpool.Pool
+}
+
+func NewExportRepository(ctx context.Context, dbCfg *config.DBConfig) (*ExportStorage, error) {
+	dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
+		dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Port, dbCfg.DBName)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &ExportStorage{db: pool}, nil
+}
+
+// Export reads the whole chat into sink. Everything is read inside a single
+// repeatable-read transaction, so messages sent while the export runs are
+// either entirely in it or not at all, however long it takes.
+func (s *ExportStorage) Export(ctx context.Context, chatID int64, batchSize int, sink models.ExportSink) error {
+	op := "repo.Export.Export"
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := exportChat(ctx, tx, chatID, sink); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := exportUsers(ctx, tx, chatID, sink); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := exportMembers(ctx, tx, chatID, sink); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := exportMessages(ctx, tx, chatID, batchSize, sink); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := exportPins(ctx, tx, chatID, sink); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func exportChat(ctx context.Context, tx pgx.Tx, chatID int64, sink models.ExportSink) error {
+	query := `
+		SELECT id, name, kind, retention_mode, retention_ttl, created_at
+		FROM chats
+		WHERE id = $1
+	`
+
+	var (
+		chat      models.Chat
+		kind      string
+		retention string
+		ttl       int64
+		createdAt time.Time
+	)
+	err := tx.QueryRow(ctx, query, chatID).Scan(&chat.ID, &chat.Name, &kind, &retention, &ttl, &createdAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrChatNotFound
+		}
+		return err
+	}
+	chat.Kind = models.ChatKind(kind)
+	chat.Retention = retentionFromRow(retention, ttl)
+
+	return sink.Chat(chat, createdAt)
+}
+
+// exportUsers sends every user the chat refers to, including former members
+// who still have messages, reactions or mentions in it.
+func exportUsers(ctx context.Context, tx pgx.Tx, chatID int64, sink models.ExportSink) error {
+	query := `
+		SELECT id, email, name
+		FROM users
+		WHERE id IN (
+			SELECT user_id FROM chat_members WHERE chat_id = $1
+			UNION
+			SELECT sender_id FROM messages WHERE chat_id = $1
+			UNION
+			SELECT unnest(mentioned_user_ids) FROM messages WHERE chat_id = $1
+			UNION
+			SELECT r.user_id
+			FROM message_reactions r
+			JOIN messages m ON m.id = r.message_id
+			WHERE m.chat_id = $1
+			UNION
+			SELECT uploader_id FROM attachments WHERE chat_id = $1 AND message_id IS NOT NULL
+			UNION
+			SELECT pinned_by FROM pinned_messages WHERE chat_id = $1
+		)
+		ORDER BY id
+	`
+
+	rows, err := tx.Query(ctx, query, chatID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(&user.ID, &user.Email, &user.Name); err != nil {
+			return err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return sink.Users(users)
+}
+
+func exportMembers(ctx context.Context, tx pgx.Tx, chatID int64, sink models.ExportSink) error {
+	query := `
+		SELECT chat_id, user_id, role, joined_at
+		FROM chat_members
+		WHERE chat_id = $1
+		ORDER BY joined_at, user_id
+	`
+
+	rows, err := tx.Query(ctx, query, chatID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var members []models.Member
+	for rows.Next() {
+		var (
+			member models.Member
+			role   string
+		)
+		if err := rows.Scan(&member.ChatID, &member.UserID, &role, &member.JoinedAt); err != nil {
+			return err
+		}
+		member.Role = models.Role(role)
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return sink.Members(members)
+}
+
+// exportMessages pages through the history by sequence number, loading the
+// attachments and reactions of each page before handing it to sink.
+func exportMessages(ctx context.Context, tx pgx.Tx, chatID int64, batchSize int, sink models.ExportSink) error {
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages
+		WHERE chat_id = $1 AND seq > $2
+		ORDER BY seq
+		LIMIT $3
+	`
+
+	var after int64
+	for {
+		rows, err := tx.Query(ctx, query, chatID, after, batchSize)
+		if err != nil {
+			return err
+		}
+
+		msgs, err := collectMessages(rows)
+		if err != nil {
+			return err
+		}
+		if len(msgs) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(msgs))
+		for _, msg := range msgs {
+			ids = append(ids, msg.ID)
+		}
+
+		attachments, err := exportAttachments(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		for i := range msgs {
+			msgs[i].Attachments = attachments[msgs[i].ID]
+		}
+
+		reactions, err := exportReactions(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+
+		if err := sink.Messages(msgs, reactions); err != nil {
+			return err
+		}
+
+		if len(msgs) < batchSize {
+			return nil
+		}
+		after = msgs[len(msgs)-1].Seq
+	}
+}
+
+// exportAttachments returns the attachments of the given messages that are
+// not deleted. Tombstones keep theirs out of exports, as downloads do.
+func exportAttachments(ctx context.Context, tx pgx.Tx, messageIDs []int64) (map[int64][]models.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments
+		WHERE message_id IN (
+			SELECT id FROM messages WHERE id = ANY($1) AND deleted_at IS NULL
+		)
+		ORDER BY id
+	`
+
+	rows, err := tx.Query(ctx, query, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[int64][]models.Attachment)
+	for rows.Next() {
+		att, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		res[att.MessageID] = append(res[att.MessageID], att)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func exportReactions(ctx context.Context, tx pgx.Tx, messageIDs []int64) (map[int64][]models.Reaction, error) {
+	query := `
+		SELECT message_id, user_id, emoji, created_at
+		FROM message_reactions
+		WHERE message_id = ANY($1)
+		ORDER BY message_id, created_at, user_id, emoji
+	`
+
+	rows, err := tx.Query(ctx, query, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[int64][]models.Reaction)
+	for rows.Next() {
+		var r models.Reaction
+		if err := rows.Scan(&r.MessageID, &r.UserID, &r.Emoji, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		res[r.MessageID] = append(res[r.MessageID], r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func exportPins(ctx context.Context, tx pgx.Tx, chatID int64, sink models.ExportSink) error {
+	query := `
+		SELECT message_id, pinned_by, pinned_at
+		FROM pinned_messages
+		WHERE chat_id = $1
+		ORDER BY pinned_at, message_id
+	`
+
+	rows, err := tx.Query(ctx, query, chatID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var pins []models.Pin
+	for rows.Next() {
+		pin := models.Pin{Message: models.Message{ChatID: chatID}}
+		if err := rows.Scan(&pin.Message.ID, &pin.PinnedBy, &pin.PinnedAt); err != nil {
+			return err
+		}
+		pins = append(pins, pin)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return sink.Pins(pins)
+}
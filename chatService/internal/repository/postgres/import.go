@@ -0,0 +1,423 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ImportStorage struct {
+	db *pgxpool.Pool
+}
+
+func NewImportRepository(ctx context.Context, dbCfg *config.DBConfig) (*ImportStorage, error) {
+	dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
+		dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Port, dbCfg.DBName)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &ImportStorage{db: pool}, nil
+}
+
+// UserIDsByEmail returns the ids of the users with the given emails, keyed by
+// lowercased email. Unknown emails are left out.
+func (s *ImportStorage) UserIDsByEmail(ctx context.Context, emails []string) (map[string]int64, error) {
+	op := "repo.Import.UserIDsByEmail"
+
+	lowered := make([]string, 0, len(emails))
+	for _, email := range emails {
+		lowered = append(lowered, strings.ToLower(email))
+	}
+
+	query := `
+		SELECT lower(email), id
+		FROM users
+		WHERE lower(email) = ANY($1)
+	`
+
+	rows, err := s.db.Query(ctx, query, lowered)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	ids := make(map[string]int64, len(emails))
+	for rows.Next() {
+		var (
+			email string
+			id    int64
+		)
+		if err := rows.Scan(&email, &id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids[email] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// Begin returns the chat an import goes into, creating it on the first run.
+// The flag reports whether an earlier run already completed the import.
+// Runs for the same source are serialised by an advisory lock on its id.
+func (s *ImportStorage) Begin(ctx context.Context, imp models.ChatImport) (int64, bool, error) {
+	op := "repo.Import.Begin"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, imp.SourceChatID); err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	selectQuery := `
+		SELECT chat_id, completed_at IS NOT NULL
+		FROM chat_imports
+		WHERE source_chat_id = $1 AND source_created_at = $2
+	`
+
+	var (
+		chatID    int64
+		completed bool
+	)
+	err = tx.QueryRow(ctx, selectQuery, imp.SourceChatID, imp.SourceCreatedAt).Scan(&chatID, &completed)
+	if err == nil {
+		return chatID, completed, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var low, high *int64
+	if imp.Chat.Kind == models.ChatKindDirect {
+		a, b := min(imp.DirectUserIDs[0], imp.DirectUserIDs[1]), max(imp.DirectUserIDs[0], imp.DirectUserIDs[1])
+		low, high = &a, &b
+	}
+
+	insertQuery := `
+		WITH chat AS (
+			INSERT INTO chats (
+				name, kind, direct_user_low, direct_user_high, retention_mode, retention_ttl, created_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $8)
+			RETURNING id
+		), registered AS (
+			INSERT INTO chat_imports (source_chat_id, source_created_at, chat_id, imported_by)
+			SELECT $7, $8, id, $9
+			FROM chat
+		)
+		SELECT id
+		FROM chat
+	`
+
+	err = tx.QueryRow(ctx, insertQuery,
+		imp.Chat.Name,
+		string(imp.Chat.Kind),
+		low,
+		high,
+		string(imp.Chat.Retention.Mode),
+		int64(imp.Chat.Retention.TTL/time.Second),
+		imp.SourceChatID,
+		imp.SourceCreatedAt,
+		imp.ImportedBy,
+	).Scan(&chatID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, false, fmt.Errorf("%s: %w", op, ErrDirectChatExists)
+		}
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return chatID, false, nil
+}
+
+// ImportedIDs maps the given source message ids to the messages an earlier
+// run already imported them as. Ids not imported yet are left out.
+func (s *ImportStorage) ImportedIDs(ctx context.Context, chatID int64, sourceIDs []int64) (map[int64]int64, error) {
+	op := "repo.Import.ImportedIDs"
+
+	query := `
+		SELECT source_message_id, message_id
+		FROM imported_messages
+		WHERE chat_id = $1 AND source_message_id = ANY($2)
+	`
+
+	rows, err := s.db.Query(ctx, query, chatID, sourceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	ids := make(map[int64]int64, len(sourceIDs))
+	for rows.Next() {
+		var sourceID, id int64
+		if err := rows.Scan(&sourceID, &id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids[sourceID] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// ImportMessages appends msgs to the chat in order, in one transaction, and
+// records which source message each became. Sequence numbers are assigned
+// here; timestamps are kept. Replies resolve their root through the messages
+// imported before, so a root missing from the import leaves a top-level
+// message.
+func (s *ImportStorage) ImportMessages(ctx context.Context, chatID int64, msgs []models.ImportedMessage) error {
+	op := "repo.Import.ImportMessages"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, msg := range msgs {
+		id, err := importMessage(ctx, tx, chatID, msg)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return fmt.Errorf("%s: %w", op, ErrImportConflict)
+			}
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, att := range msg.Message.Attachments {
+			if err := importAttachment(ctx, tx, chatID, id, att); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func importMessage(ctx context.Context, tx pgx.Tx, chatID int64, msg models.ImportedMessage) (int64, error) {
+	query := `
+		WITH next AS (
+			UPDATE chats
+			SET last_seq = last_seq + 1
+			WHERE id = $1
+			RETURNING last_seq
+		), inserted AS (
+			INSERT INTO messages (
+				chat_id, sender_id, text, seq, created_at, edited_at, deleted_at,
+				reply_to_id, mentioned_user_ids
+			)
+			SELECT $1, $2, $3, last_seq, $4, $5, $6, (
+				SELECT message_id
+				FROM imported_messages
+				WHERE chat_id = $1 AND source_message_id = $7
+			), $8
+			FROM next
+			RETURNING id, created_at, deleted_at, reply_to_id
+		), root AS (
+			UPDATE messages
+			SET reply_count = reply_count + 1, last_reply_at = inserted.created_at
+			FROM inserted
+			WHERE messages.id = inserted.reply_to_id AND inserted.deleted_at IS NULL
+		), mapped AS (
+			INSERT INTO imported_messages (chat_id, source_message_id, message_id)
+			SELECT $1, $9, id
+			FROM inserted
+		), reactions AS (
+			INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+			SELECT inserted.id, r.user_id, r.emoji, r.created_at
+			FROM inserted, unnest($10::bigint[], $11::text[], $12::timestamp[]) AS r(user_id, emoji, created_at)
+			ON CONFLICT DO NOTHING
+		)
+		SELECT id
+		FROM inserted
+	`
+
+	mentioned := msg.Message.MentionedUserIDs
+	if mentioned == nil {
+		mentioned = []int64{}
+	}
+
+	var (
+		userIDs   = make([]int64, 0, len(msg.Reactions))
+		emojis    = make([]string, 0, len(msg.Reactions))
+		reactedAt = make([]time.Time, 0, len(msg.Reactions))
+	)
+	for _, r := range msg.Reactions {
+		userIDs = append(userIDs, r.UserID)
+		emojis = append(emojis, r.Emoji)
+		reactedAt = append(reactedAt, r.CreatedAt)
+	}
+
+	var id int64
+	err := tx.QueryRow(ctx, query,
+		chatID,
+		msg.Message.SenderID,
+		msg.Message.Text,
+		msg.Message.CreatedAt,
+		msg.Message.EditedAt,
+		msg.Message.DeletedAt,
+		msg.SourceReplyToID,
+		mentioned,
+		msg.SourceID,
+		userIDs,
+		emojis,
+		reactedAt,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrChatNotFound
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func importAttachment(ctx context.Context, tx pgx.Tx, chatID, messageID int64, att models.Attachment) error {
+	query := `
+		INSERT INTO attachments (
+			chat_id, uploader_id, message_id, filename, content_type, size,
+			storage_key, created_at, width, height
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	var id int64
+	err := tx.QueryRow(ctx, query,
+		chatID,
+		att.UploaderID,
+		messageID,
+		att.Filename,
+		att.ContentType,
+		att.Size,
+		att.StorageKey,
+		att.CreatedAt,
+		att.Width,
+		att.Height,
+	).Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	thumbQuery := `
+		INSERT INTO attachment_thumbnails (attachment_id, width, height, content_type, size, storage_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for _, thumb := range att.Thumbnails {
+		_, err := tx.Exec(ctx, thumbQuery, id, thumb.Width, thumb.Height, thumb.ContentType, thumb.Size, thumb.StorageKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Complete adds the members and pins of an import and marks it completed.
+// Members start with everything read, so imported history does not show up
+// as unread. Pins of messages that were not imported are dropped.
+func (s *ImportStorage) Complete(ctx context.Context, chatID int64, members []models.Member, pins []models.ImportedPin) error {
+	op := "repo.Import.Complete"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		userIDs  = make([]int64, 0, len(members))
+		roles    = make([]string, 0, len(members))
+		joinedAt = make([]time.Time, 0, len(members))
+	)
+	for _, m := range members {
+		userIDs = append(userIDs, m.UserID)
+		roles = append(roles, string(m.Role))
+		joinedAt = append(joinedAt, m.JoinedAt)
+	}
+
+	membersQuery := `
+		INSERT INTO chat_members (chat_id, user_id, role, joined_at, last_read_message_id, last_read_seq)
+		SELECT $1, m.user_id, m.role, m.joined_at, COALESCE(latest.id, 0), c.last_seq
+		FROM unnest($2::bigint[], $3::text[], $4::timestamp[]) AS m(user_id, role, joined_at)
+		JOIN chats c ON c.id = $1
+		LEFT JOIN LATERAL (
+			SELECT id
+			FROM messages
+			WHERE chat_id = $1
+			ORDER BY seq DESC
+			LIMIT 1
+		) latest ON true
+		ON CONFLICT (chat_id, user_id) DO NOTHING
+	`
+	if _, err := tx.Exec(ctx, membersQuery, chatID, userIDs, roles, joinedAt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var (
+		sourceIDs = make([]int64, 0, len(pins))
+		pinnedBy  = make([]int64, 0, len(pins))
+		pinnedAt  = make([]time.Time, 0, len(pins))
+	)
+	for _, p := range pins {
+		sourceIDs = append(sourceIDs, p.SourceMessageID)
+		pinnedBy = append(pinnedBy, p.PinnedBy)
+		pinnedAt = append(pinnedAt, p.PinnedAt)
+	}
+
+	pinsQuery := `
+		INSERT INTO pinned_messages (message_id, chat_id, pinned_by, pinned_at)
+		SELECT i.message_id, $1, p.pinned_by, p.pinned_at
+		FROM unnest($2::bigint[], $3::bigint[], $4::timestamp[]) AS p(source_id, pinned_by, pinned_at)
+		JOIN imported_messages i ON i.chat_id = $1 AND i.source_message_id = p.source_id
+		JOIN messages m ON m.id = i.message_id AND m.deleted_at IS NULL
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(ctx, pinsQuery, chatID, sourceIDs, pinnedBy, pinnedAt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE chat_imports SET completed_at = now() WHERE chat_id = $1`, chatID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MemberStorage struct {
+	db *pgxpool.Pool
+}
+
+func NewMemberRepository(ctx context.Context, dbCfg *config.DBConfig) (*MemberStorage, error) {
+	dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
+		dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Port, dbCfg.DBName)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &MemberStorage{db: pool}, nil
+}
+
+// Add makes the user a member of the chat. New members start with everything
+// already in the chat marked as read.
+func (s *MemberStorage) Add(ctx context.Context, chatID, userID int64, role models.Role) error {
+	op := "repo.Member.Add"
+
+	query := `
+		INSERT INTO chat_members (chat_id, user_id, role, last_read_seq, last_read_message_id)
+		SELECT id, $2, $3, last_seq, COALESCE(
+			(SELECT max(id) FROM messages WHERE chat_id = $1), 0)
+		FROM chats
+		WHERE id = $1
+	`
+	tag, err := s.db.Exec(ctx, query, chatID, userID, string(role))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%s: %w", op, ErrMemberExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrChatNotFound)
+	}
+
+	return nil
+}
+
+func (s *MemberStorage) Remove(ctx context.Context, chatID, userID int64) error {
+	op := "repo.Member.Remove"
+
+	query := `
+		DELETE FROM chat_members
+		WHERE chat_id = $1 AND user_id = $2
+	`
+	tag, err := s.db.Exec(ctx, query, chatID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrMemberNotFound)
+	}
+
+	return nil
+}
+
+func (s *MemberStorage) Get(ctx context.Context, chatID, userID int64) (models.Member, error) {
+	op := "repo.Member.Get"
+
+	query := `
+		SELECT chat_id, user_id, role, joined_at
+		FROM chat_members
+		WHERE chat_id = $1 AND user_id = $2
+	`
+
+	member, err := scanMember(s.db.QueryRow(ctx, query, chatID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Member{}, fmt.Errorf("%s: %w", op, ErrMemberNotFound)
+		}
+		return models.Member{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return member, nil
+}
+
+func (s *MemberStorage) SetRole(ctx context.Context, chatID, userID int64, role models.Role) error {
+	op := "repo.Member.SetRole"
+
+	query := `
+		UPDATE chat_members
+		SET role = $3
+		WHERE chat_id = $1 AND user_id = $2
+	`
+	tag, err := s.db.Exec(ctx, query, chatID, userID, string(role))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrMemberNotFound)
+	}
+
+	return nil
+}
+
+// MarkRead moves the member's read cursor forward to the given message. It
+// reports false when the cursor was already at or past it.
+func (s *MemberStorage) MarkRead(ctx context.Context, chatID, userID, messageID, seq int64) (bool, error) {
+	op := "repo.Member.MarkRead"
+
+	query := `
+		UPDATE chat_members
+		SET last_read_message_id = $3, last_read_seq = $4
+		WHERE chat_id = $1 AND user_id = $2 AND last_read_seq < $4
+	`
+	tag, err := s.db.Exec(ctx, query, chatID, userID, messageID, seq)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (s *MemberStorage) List(ctx context.Context, chatID int64) ([]models.Member, error) {
+	op := "repo.Member.List"
+
+	query := `
+		SELECT m.chat_id, m.user_id, m.role, m.joined_at, COALESCE(h.handle, '')
+		FROM chat_members m
+		LEFT JOIN user_handles h ON h.user_id = m.user_id
+		WHERE m.chat_id = $1
+		ORDER BY m.joined_at, m.user_id
+	`
+
+	rows, err := s.db.Query(ctx, query, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var members []models.Member
+	for rows.Next() {
+		var (
+			member models.Member
+			role   string
+		)
+		if err := rows.Scan(&member.ChatID, &member.UserID, &role, &member.JoinedAt, &member.Handle); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		member.Role = models.Role(role)
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return members, nil
+}
+
+// ChatIDs returns the ids of all chats the user is a member of.
+func (s *MemberStorage) ChatIDs(ctx context.Context, userID int64) ([]int64, error) {
+	op := "repo.Member.ChatIDs"
+
+	query := `SELECT chat_id FROM chat_members WHERE user_id = $1`
+
+	rows, err := s.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// ResolveMentions returns the ids of the chat members whose handle is one of
+// the lowercased handles, or of all members when everyone is set.
+func (s *MemberStorage) ResolveMentions(ctx context.Context, chatID int64, handles []string, everyone bool) ([]int64, error) {
+	op := "repo.Member.ResolveMentions"
+
+	query := `
+		SELECT m.user_id
+		FROM chat_members m
+		LEFT JOIN user_handles h ON h.user_id = m.user_id
+		WHERE m.chat_id = $1 AND ($3 OR h.handle = ANY($2))
+		ORDER BY m.user_id
+	`
+
+	rows, err := s.db.Query(ctx, query, chatID, handles, everyone)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+func scanMember(row pgx.Row) (models.Member, error) {
+	var (
+		member models.Member
+		role   string
+	)
+	err := row.Scan(&member.ChatID, &member.UserID, &role, &member.JoinedAt)
+	member.Role = models.Role(role)
+	return member, err
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MentionStorage is the mention inbox. Mentions are written together with
+// their message by MessageStorage.Create.
+type MentionStorage struct {
+	db *pgxpool.Pool
+}
+
+func NewMentionRepository(ctx context.Context, dbCfg *config.DBConfig) (*MentionStorage, error) {
+	dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
+		dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Port, dbCfg.DBName)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &MentionStorage{db: pool}, nil
+}
+
+// List returns up to limit mentions of the user below cursor, newest first.
+// Mentions of deleted messages and of chats the user has left are skipped.
+func (s *MentionStorage) List(
+	ctx context.Context,
+	userID int64,
+	cursor int64,
+	unreadOnly bool,
+	limit int,
+) ([]models.Notification, error) {
+	op := "repo.Mention.List"
+
+	query := `
+		SELECT n.id, n.created_at, n.read_at, m.*
+		FROM mentions n
+		CROSS JOIN LATERAL (
+			SELECT ` + messageColumns + `
+			FROM messages
+			WHERE id = n.message_id AND deleted_at IS NULL
+		) m
+		WHERE n.user_id = $1
+			AND n.id < $2
+			AND (NOT $3 OR n.read_at IS NULL)
+			AND EXISTS (
+				SELECT 1 FROM chat_members
+				WHERE chat_id = n.chat_id AND user_id = n.user_id
+			)
+		ORDER BY n.id DESC
+		LIMIT $4
+	`
+
+	if cursor == 0 {
+		cursor = math.MaxInt64
+	}
+
+	rows, err := s.db.Query(ctx, query, userID, cursor, unreadOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		n := models.Notification{UserID: userID}
+		dest := append([]any{&n.ID, &n.CreatedAt, &n.ReadAt}, messageFields(&n.Message)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return notifications, nil
+}
+
+// MarkRead marks a mention of the user read. Marking it again keeps the
+// original read time.
+func (s *MentionStorage) MarkRead(ctx context.Context, id, userID int64) error {
+	op := "repo.Mention.MarkRead"
+
+	query := `
+		UPDATE mentions
+		SET read_at = COALESCE(read_at, now())
+		WHERE id = $1 AND user_id = $2
+	`
+	tag, err := s.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNotificationNotFound)
+	}
+
+	return nil
+}
+
+// SetHandle sets or replaces the handle of the user. The handle must be
+// lowercased; ErrHandleTaken is returned if another user holds it.
+func (s *MentionStorage) SetHandle(ctx context.Context, userID int64, handle string) error {
+	op := "repo.Mention.SetHandle"
+
+	query := `
+		INSERT INTO user_handles (user_id, handle)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET handle = EXCLUDED.handle
+	`
+	if _, err := s.db.Exec(ctx, query, userID, handle); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%s: %w", op, ErrHandleTaken)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -1 +1,882 @@
 package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// messageColumns is the column list scanMessage expects, in order.
+const messageColumns = `
+	id, chat_id, sender_id, text, seq, created_at, edited_at, deleted_at,
+	COALESCE(reply_to_id, 0), reply_count, last_reply_at, mentioned_user_ids`
+
+type MessageStorage struct {
+	db *pgxpool.Pool
+}
+
+func NewMessageRepository(ctx context.Context, dbCfg *config.DBConfig) (*MessageStorage, error) {
+	dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
+		dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Port, dbCfg.DBName)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &MessageStorage{db: pool}, nil
+}
+
+// Create stores a message and assigns it the next sequence number of its chat.
+// Only the chat, sender, text, reply target and mentions of msg are used.
+// Bumping chats.last_seq locks the chat row until commit, so sequence numbers
+// are gap-free and become visible in order. A non-zero ReplyToID must be the
+// root of a thread in the same chat; its reply counters are updated as well
+// and returned in the message's Thread.
+// The sender's own read cursor is moved past the new message and every
+// mentioned user gets a mention in their inbox. The attachments must be unsent
+// uploads of the sender to the same chat; otherwise nothing is stored and
+// ErrAttachmentNotFound is returned.
+func (s *MessageStorage) Create(ctx context.Context, msg models.Message, attachmentIDs []int64) (models.Message, error) {
+	op := "repo.Message.Create"
+
+	query := `
+		WITH next AS (
+			UPDATE chats
+			SET last_seq = last_seq + 1
+			WHERE id = $1
+			RETURNING last_seq
+		), inserted AS (
+			INSERT INTO messages (chat_id, sender_id, text, seq, reply_to_id, mentioned_user_ids)
+			SELECT $1, $2, $3, last_seq, $4, $6
+			FROM next
+			RETURNING *
+		), root AS (
+			UPDATE messages
+			SET reply_count = reply_count + 1, last_reply_at = inserted.created_at
+			FROM inserted
+			WHERE messages.id = inserted.reply_to_id
+			RETURNING messages.reply_count, messages.last_reply_at
+		), sender AS (
+			UPDATE chat_members
+			SET last_read_message_id = inserted.id, last_read_seq = inserted.seq
+			FROM inserted
+			WHERE chat_members.chat_id = inserted.chat_id AND chat_members.user_id = inserted.sender_id
+		), attached AS (
+			UPDATE attachments
+			SET message_id = inserted.id
+			FROM inserted
+			WHERE attachments.id = ANY($5)
+				AND attachments.chat_id = inserted.chat_id
+				AND attachments.uploader_id = inserted.sender_id
+				AND attachments.message_id IS NULL
+			RETURNING attachments.id
+		), mentioned AS (
+			INSERT INTO mentions (user_id, chat_id, message_id)
+			SELECT unnest(inserted.mentioned_user_ids), inserted.chat_id, inserted.id
+			FROM inserted
+		)
+		SELECT ` + messageColumns + `,
+			(SELECT count(*) FROM attached),
+			(SELECT reply_count FROM root),
+			(SELECT last_reply_at FROM root)
+		FROM inserted
+	`
+
+	var replyTo *int64
+	if msg.ReplyToID != 0 {
+		replyTo = &msg.ReplyToID
+	}
+
+	mentioned := msg.MentionedUserIDs
+	if mentioned == nil {
+		mentioned = []int64{}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		res         models.Message
+		attached    int
+		replyCount  *int64
+		lastReplyAt *time.Time
+	)
+	row := tx.QueryRow(ctx, query, msg.ChatID, msg.SenderID, msg.Text, replyTo, attachmentIDs, mentioned)
+	if err := row.Scan(append(messageFields(&res), &attached, &replyCount, &lastReplyAt)...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Message{}, fmt.Errorf("%s: %w", op, ErrChatNotFound)
+		}
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if attached != len(attachmentIDs) {
+		return models.Message{}, fmt.Errorf("%s: %w", op, ErrAttachmentNotFound)
+	}
+
+	if replyCount != nil && lastReplyAt != nil {
+		res.Thread = &models.Thread{
+			RootID:      res.ReplyToID,
+			ReplyCount:  *replyCount,
+			LastReplyAt: *lastReplyAt,
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+func (s *MessageStorage) Get(ctx context.Context, id int64) (models.Message, error) {
+	op := "repo.Message.Get"
+
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages
+		WHERE id = $1
+	`
+
+	msg, err := scanMessage(s.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Message{}, fmt.Errorf("%s: %w", op, ErrMessageNotFound)
+		}
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msg, nil
+}
+
+func (s *MessageStorage) GetList(ctx context.Context, chatID int64) ([]models.Message, error) {
+	op := "repo.Message.GetList"
+
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages
+		WHERE chat_id = $1
+		ORDER BY id
+	`
+
+	rows, err := s.db.Query(ctx, query, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msgs, err := collectMessages(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msgs, nil
+}
+
+// GetPage returns up to limit messages of a chat on the given side of cursor,
+// newest first. A zero cursor pages from the newest message.
+func (s *MessageStorage) GetPage(
+	ctx context.Context,
+	chatID int64,
+	cursor int64,
+	direction models.Direction,
+	limit int,
+) ([]models.Message, error) {
+	op := "repo.Message.GetPage"
+
+	msgs, err := s.page(ctx, "chat_id", chatID, cursor, direction, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msgs, nil
+}
+
+// GetAfterSeq returns up to limit messages of a chat with a sequence number
+// above seq, oldest first.
+func (s *MessageStorage) GetAfterSeq(ctx context.Context, chatID, seq int64, limit int) ([]models.Message, error) {
+	op := "repo.Message.GetAfterSeq"
+
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages
+		WHERE chat_id = $1 AND seq > $2
+		ORDER BY seq
+		LIMIT $3
+	`
+
+	rows, err := s.db.Query(ctx, query, chatID, seq, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msgs, err := collectMessages(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msgs, nil
+}
+
+// SeqAt returns the sequence number of the newest message of a chat with an
+// id of at most messageID, or zero if there is none. It still finds the
+// position of a message that has been purged since.
+func (s *MessageStorage) SeqAt(ctx context.Context, chatID, messageID int64) (int64, error) {
+	op := "repo.Message.SeqAt"
+
+	query := `
+		SELECT seq
+		FROM messages
+		WHERE chat_id = $1 AND id <= $2
+		ORDER BY id DESC
+		LIMIT 1
+	`
+
+	var seq int64
+	if err := s.db.QueryRow(ctx, query, chatID, messageID).Scan(&seq); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return seq, nil
+}
+
+// GetLatest returns the newest message of each of the given chats that has
+// any, keyed by chat id. Each lookup is a single (chat_id, id) index probe.
+func (s *MessageStorage) GetLatest(ctx context.Context, chatIDs []int64) (map[int64]models.Message, error) {
+	op := "repo.Message.GetLatest"
+
+	query := `
+		SELECT m.*
+		FROM unnest($1::bigint[]) AS c(id)
+		CROSS JOIN LATERAL (
+			SELECT ` + messageColumns + `
+			FROM messages
+			WHERE chat_id = c.id
+			ORDER BY id DESC
+			LIMIT 1
+		) m
+	`
+
+	rows, err := s.db.Query(ctx, query, chatIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msgs, err := collectMessages(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	latest := make(map[int64]models.Message, len(msgs))
+	for _, msg := range msgs {
+		latest[msg.ChatID] = msg
+	}
+
+	return latest, nil
+}
+
+// GetThreadPage pages through the replies of a thread root like GetPage.
+func (s *MessageStorage) GetThreadPage(
+	ctx context.Context,
+	rootID int64,
+	cursor int64,
+	direction models.Direction,
+	limit int,
+) ([]models.Message, error) {
+	op := "repo.Message.GetThreadPage"
+
+	msgs, err := s.page(ctx, "reply_to_id", rootID, cursor, direction, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msgs, nil
+}
+
+// page runs a keyset query over messages where column equals key. column is
+// always a constant supplied by the caller, never user input.
+func (s *MessageStorage) page(
+	ctx context.Context,
+	column string,
+	key int64,
+	cursor int64,
+	direction models.Direction,
+	limit int,
+) ([]models.Message, error) {
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages
+		WHERE ` + column + ` = $1 AND id < $2
+		ORDER BY id DESC
+		LIMIT $3
+	`
+	if direction == models.DirectionNewer {
+		query = `
+			SELECT ` + messageColumns + `
+			FROM messages
+			WHERE ` + column + ` = $1 AND id > $2
+			ORDER BY id
+			LIMIT $3
+		`
+	} else if cursor == 0 {
+		cursor = math.MaxInt64
+	}
+
+	rows, err := s.db.Query(ctx, query, key, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := collectMessages(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if direction == models.DirectionNewer {
+		slices.Reverse(msgs)
+	}
+
+	return msgs, nil
+}
+
+// Search returns up to limit live messages matching the filter from chats the
+// user is a member of, newest first, starting below cursor. A zero cursor
+// starts from the newest match. The text is HTML-escaped before the matches
+// in the snippet are wrapped in <mark> tags, so the snippet is safe markup.
+func (s *MessageStorage) Search(
+	ctx context.Context,
+	userID int64,
+	filter models.SearchFilter,
+	cursor int64,
+	limit int,
+) ([]models.SearchResult, error) {
+	op := "repo.Message.Search"
+
+	query := `
+		SELECT ` + messageColumns + `,
+			ts_headline(
+				'simple',
+				replace(replace(replace(text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+				q,
+				'StartSel=<mark>, StopSel=</mark>, MaxFragments=2'
+			)
+		FROM messages, websearch_to_tsquery('simple', $2) AS q
+		WHERE search_vector @@ q
+			AND deleted_at IS NULL
+			AND chat_id IN (SELECT chat_id FROM chat_members WHERE user_id = $1)
+			AND (COALESCE(cardinality($3::bigint[]), 0) = 0 OR chat_id = ANY($3))
+			AND ($4::bigint = 0 OR sender_id = $4)
+			AND ($5::timestamp IS NULL OR created_at >= $5)
+			AND ($6::timestamp IS NULL OR created_at < $6)
+			AND id < $7
+		ORDER BY id DESC
+		LIMIT $8
+	`
+
+	if cursor == 0 {
+		cursor = math.MaxInt64
+	}
+
+	rows, err := s.db.Query(ctx, query,
+		userID, filter.Query, filter.ChatIDs, filter.SenderID, filter.From, filter.To, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var results []models.SearchResult
+	for rows.Next() {
+		var res models.SearchResult
+		if err := rows.Scan(append(messageFields(&res.Message), &res.Snippet)...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		results = append(results, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// Update replaces the text and mentions of a message that has not been
+// deleted. Users no longer mentioned lose the mention from their inbox; newly
+// mentioned ones get one.
+func (s *MessageStorage) Update(ctx context.Context, id int64, text string, mentioned []int64) (models.Message, error) {
+	op := "repo.Message.Update"
+
+	query := `
+		WITH updated AS (
+			UPDATE messages
+			SET text = $2, mentioned_user_ids = $3, edited_at = now()
+			WHERE id = $1 AND deleted_at IS NULL
+			RETURNING *
+		), unmentioned AS (
+			DELETE FROM mentions
+			WHERE message_id IN (SELECT id FROM updated) AND NOT user_id = ANY($3)
+		), mentioned AS (
+			INSERT INTO mentions (user_id, chat_id, message_id)
+			SELECT unnest(updated.mentioned_user_ids), updated.chat_id, updated.id
+			FROM updated
+			ON CONFLICT (message_id, user_id) DO NOTHING
+		)
+		SELECT ` + messageColumns + `
+		FROM updated
+	`
+
+	if mentioned == nil {
+		mentioned = []int64{}
+	}
+
+	msg, err := scanMessage(s.db.QueryRow(ctx, query, id, text, mentioned))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Message{}, fmt.Errorf("%s: %w", op, ErrMessageDeleted)
+		}
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msg, nil
+}
+
+// Delete turns a message into a tombstone: the row is kept so history and
+// sequence numbers stay intact, but its text, mentions and reactions are
+// cleared and it is unpinned. Mentions, reactions and the pin are removed by
+// statements of their own after the message row is locked, so they also catch
+// ones committed while Delete waited for the lock. It reports whether the message was pinned. A
+// deleted reply no longer counts towards its thread; the root's new counters
+// are returned in the message's Thread. Attachments are dropped as well, and
+// keys lists the blobs of them and their thumbnails for the caller to remove.
+func (s *MessageStorage) Delete(ctx context.Context, id int64) (models.Message, bool, []string, error) {
+	op := "repo.Message.Delete"
+
+	query := `
+		UPDATE messages
+		SET text = '', mentioned_user_ids = '{}', deleted_at = now()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + messageColumns
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return models.Message{}, false, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	msg, err := scanMessage(tx.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Message{}, false, nil, fmt.Errorf("%s: %w", op, ErrMessageDeleted)
+		}
+		return models.Message{}, false, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mentions WHERE message_id = $1`, id); err != nil {
+		return models.Message{}, false, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM message_reactions WHERE message_id = $1`, id); err != nil {
+		return models.Message{}, false, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM pinned_messages WHERE message_id = $1`, id)
+	if err != nil {
+		return models.Message{}, false, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	keys, err := dropAttachments(ctx, tx, []int64{id})
+	if err != nil {
+		return models.Message{}, false, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if msg.ReplyToID != 0 {
+		roots, err := refreshThreads(ctx, tx, []int64{msg.ReplyToID})
+		if err != nil {
+			return models.Message{}, false, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for _, root := range roots {
+			msg.Thread = &models.Thread{RootID: root.ID, ReplyCount: root.ReplyCount}
+			if root.LastReplyAt != nil {
+				msg.Thread.LastReplyAt = *root.LastReplyAt
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.Message{}, false, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msg, tag.RowsAffected() > 0, keys, nil
+}
+
+// Expire removes up to limit messages that outlived the retention of their
+// chat, oldest first within each chat, walking each chat's messages by
+// creation time. Messages no reply refers to are purged; thread roots with
+// replies still around are tombstoned instead and purged once the replies are
+// gone. Attachments of every expired message are dropped either way. Chats
+// whose import has not completed are left alone: purging their messages would
+// drop the rows a resumed import relies on to skip them. Expired messages are
+// unpinned too.
+func (s *MessageStorage) Expire(ctx context.Context, limit int) (models.Expired, error) {
+	op := "repo.Message.Expire"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	selectQuery := `
+		SELECT e.id, e.chat_id, e.seq, e.has_replies
+		FROM chats c
+		CROSS JOIN LATERAL (
+			SELECT
+				m.id,
+				m.chat_id,
+				m.seq,
+				EXISTS (SELECT 1 FROM messages r WHERE r.reply_to_id = m.id) AS has_replies
+			FROM messages m
+			WHERE m.chat_id = c.id
+				AND m.created_at < now() - c.retention_ttl * interval '1 second'
+				AND (
+					m.deleted_at IS NULL
+					OR NOT EXISTS (SELECT 1 FROM messages r WHERE r.reply_to_id = m.id)
+				)
+			ORDER BY m.created_at
+			LIMIT $1
+			FOR UPDATE OF m SKIP LOCKED
+		) e
+		WHERE c.retention_mode <> 'forever'
+			AND NOT EXISTS (
+				SELECT 1 FROM chat_imports i
+				WHERE i.chat_id = c.id AND i.completed_at IS NULL
+			)
+		LIMIT $1
+	`
+
+	rows, err := tx.Query(ctx, selectQuery, limit)
+	if err != nil {
+		return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var (
+		ids       []int64
+		purgeIDs  []int64
+		keepIDs   []int64
+		chatOfMsg = make(map[int64]int64)
+		maxSeq    = make(map[int64]int64)
+	)
+	for rows.Next() {
+		var (
+			id, chatID, seq int64
+			hasReplies      bool
+		)
+		if err := rows.Scan(&id, &chatID, &seq, &hasReplies); err != nil {
+			rows.Close()
+			return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+		}
+		ids = append(ids, id)
+		chatOfMsg[id] = chatID
+		maxSeq[chatID] = max(maxSeq[chatID], seq)
+		if hasReplies {
+			keepIDs = append(keepIDs, id)
+		} else {
+			purgeIDs = append(purgeIDs, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(ids) == 0 {
+		return models.Expired{}, nil
+	}
+
+	var res models.Expired
+	if res.BlobKeys, err = dropAttachments(ctx, tx, ids); err != nil {
+		return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Pins are removed up front, as purging would drop them silently.
+	unpinQuery := `
+		DELETE FROM pinned_messages
+		WHERE message_id = ANY($1)
+		RETURNING message_id, chat_id
+	`
+
+	rows, err = tx.Query(ctx, unpinQuery, ids)
+	if err != nil {
+		return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+	}
+	for rows.Next() {
+		var msg models.Message
+		if err := rows.Scan(&msg.ID, &msg.ChatID); err != nil {
+			rows.Close()
+			return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+		}
+		res.Unpinned = append(res.Unpinned, msg)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+
+	if len(purgeIDs) > 0 {
+		// The roots of purged replies are recounted. A root is never purged
+		// in the same batch as its replies, since it still had them when it
+		// was selected.
+		purgeQuery := `
+			WITH purged AS (
+				DELETE FROM messages
+				WHERE id = ANY($1)
+				RETURNING reply_to_id
+			)
+			SELECT DISTINCT reply_to_id
+			FROM purged
+			WHERE reply_to_id IS NOT NULL
+		`
+
+		rows, err := tx.Query(ctx, purgeQuery, purgeIDs)
+		if err != nil {
+			return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+		}
+		var rootIDs []int64
+		for rows.Next() {
+			var rootID int64
+			if err := rows.Scan(&rootID); err != nil {
+				rows.Close()
+				return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+			}
+			rootIDs = append(rootIDs, rootID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if len(rootIDs) > 0 {
+			if res.Roots, err = refreshThreads(ctx, tx, rootIDs); err != nil {
+				return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		for _, id := range purgeIDs {
+			res.Messages = append(res.Messages, models.Message{ID: id, ChatID: chatOfMsg[id], DeletedAt: &now})
+		}
+	}
+
+	if len(keepIDs) > 0 {
+		tombstoneQuery := `
+			WITH reactions AS (
+				DELETE FROM message_reactions
+				WHERE message_id = ANY($1)
+			), mentions AS (
+				DELETE FROM mentions
+				WHERE message_id = ANY($1)
+			)
+			UPDATE messages
+			SET text = '', mentioned_user_ids = '{}', deleted_at = now()
+			WHERE id = ANY($1) AND deleted_at IS NULL
+			RETURNING id, chat_id, deleted_at
+		`
+
+		rows, err := tx.Query(ctx, tombstoneQuery, keepIDs)
+		if err != nil {
+			return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+		}
+		for rows.Next() {
+			var msg models.Message
+			if err := rows.Scan(&msg.ID, &msg.ChatID, &msg.DeletedAt); err != nil {
+				rows.Close()
+				return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+			}
+			res.Messages = append(res.Messages, msg)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// Move each chat's first live seq past what expired, so unread counts
+	// skip it. With no live message left in sight, everything up to the
+	// newest expired one is gone; messages sent meanwhile come after it.
+	liveQuery := `
+		INSERT INTO chat_live_seqs (chat_id, min_live_seq)
+		SELECT e.chat_id, COALESCE(
+			(SELECT min(m.seq) FROM messages m WHERE m.chat_id = e.chat_id AND m.deleted_at IS NULL),
+			e.max_seq + 1
+		)
+		FROM unnest($1::bigint[], $2::bigint[]) AS e(chat_id, max_seq)
+		ON CONFLICT (chat_id) DO UPDATE
+		SET min_live_seq = GREATEST(chat_live_seqs.min_live_seq, EXCLUDED.min_live_seq)
+	`
+
+	chatIDs := make([]int64, 0, len(maxSeq))
+	seqs := make([]int64, 0, len(maxSeq))
+	for chatID, seq := range maxSeq {
+		chatIDs = append(chatIDs, chatID)
+		seqs = append(seqs, seq)
+	}
+	if _, err := tx.Exec(ctx, liveQuery, chatIDs, seqs); err != nil {
+		return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.Expired{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// dropAttachments deletes the attachments of the given messages and returns
+// the storage keys of their blobs and thumbnails, which the caller removes
+// once the transaction committed.
+func dropAttachments(ctx context.Context, tx pgx.Tx, messageIDs []int64) ([]string, error) {
+	query := `
+		WITH dropped AS (
+			DELETE FROM attachments
+			WHERE message_id = ANY($1)
+			RETURNING id, storage_key
+		)
+		SELECT storage_key FROM dropped
+		UNION ALL
+		SELECT t.storage_key
+		FROM attachment_thumbnails t
+		JOIN dropped d ON d.id = t.attachment_id
+	`
+
+	rows, err := tx.Query(ctx, query, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// refreshThreads recounts the replies of thread roots: reply_count is the
+// number of replies that are not deleted and last_reply_at the time of the
+// newest of them. The roots are locked first, so the count taken afterwards
+// includes replies sent while waiting. It returns the roots with ID, ChatID
+// and their new counters.
+func refreshThreads(ctx context.Context, tx pgx.Tx, rootIDs []int64) ([]models.Message, error) {
+	lockQuery := `
+		SELECT id
+		FROM messages
+		WHERE id = ANY($1)
+		ORDER BY id
+		FOR UPDATE
+	`
+
+	if _, err := tx.Exec(ctx, lockQuery, rootIDs); err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE messages r
+		SET reply_count = t.n, last_reply_at = t.last_reply_at
+		FROM (
+			SELECT root.id, count(m.id) AS n, max(m.created_at) AS last_reply_at
+			FROM unnest($1::bigint[]) AS root(id)
+			LEFT JOIN messages m ON m.reply_to_id = root.id AND m.deleted_at IS NULL
+			GROUP BY root.id
+		) t
+		WHERE r.id = t.id
+		RETURNING r.id, r.chat_id, r.reply_count, r.last_reply_at
+	`
+
+	rows, err := tx.Query(ctx, query, rootIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roots []models.Message
+	for rows.Next() {
+		var root models.Message
+		if err := rows.Scan(&root.ID, &root.ChatID, &root.ReplyCount, &root.LastReplyAt); err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roots, nil
+}
+
+func scanMessage(row pgx.Row) (models.Message, error) {
+	var msg models.Message
+	err := row.Scan(messageFields(&msg)...)
+	return msg, err
+}
+
+// messageFields returns the scan destinations for messageColumns.
+func messageFields(msg *models.Message) []any {
+	return []any{
+		&msg.ID,
+		&msg.ChatID,
+		&msg.SenderID,
+		&msg.Text,
+		&msg.Seq,
+		&msg.CreatedAt,
+		&msg.EditedAt,
+		&msg.DeletedAt,
+		&msg.ReplyToID,
+		&msg.ReplyCount,
+		&msg.LastReplyAt,
+		&msg.MentionedUserIDs,
+	}
+}
+
+func collectMessages(rows pgx.Rows) ([]models.Message, error) {
+	defer rows.Close()
+
+	var msgs []models.Message
+	for rows.Next() {
+		msg, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PinStorage struct {
+	db *pgxpool.Pool
+}
+
+func NewPinRepository(ctx context.Context, dbCfg *config.DBConfig) (*PinStorage, error) {
+	dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
+		dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Port, dbCfg.DBName)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &PinStorage{db: pool}, nil
+}
+
+// Pin pins a message of the chat unless the chat already has limit pins or the
+// message is deleted. The chat row is locked while counting so concurrent pins
+// cannot overshoot.
+func (s *PinStorage) Pin(ctx context.Context, chatID, messageID, userID int64, limit int) (time.Time, error) {
+	op := "repo.Pin.Pin"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `SELECT 1 FROM chats WHERE id = $1 FOR UPDATE`, chatID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return time.Time{}, fmt.Errorf("%s: %w", op, ErrChatNotFound)
+	}
+
+	// Deleting the message locks its row too, so a pin racing a delete either
+	// sees the tombstone here or is removed by the delete.
+	var deleted bool
+	err = tx.QueryRow(ctx, `
+		SELECT deleted_at IS NOT NULL
+		FROM messages
+		WHERE id = $1 AND chat_id = $2
+		FOR SHARE
+	`, messageID, chatID).Scan(&deleted)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, fmt.Errorf("%s: %w", op, ErrMessageNotFound)
+		}
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if deleted {
+		return time.Time{}, fmt.Errorf("%s: %w", op, ErrMessageDeleted)
+	}
+
+	var count int
+	err = tx.QueryRow(ctx, `SELECT count(*) FROM pinned_messages WHERE chat_id = $1`, chatID).Scan(&count)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if count >= limit {
+		return time.Time{}, fmt.Errorf("%s: %w", op, ErrPinLimitReached)
+	}
+
+	query := `
+		INSERT INTO pinned_messages (message_id, chat_id, pinned_by)
+		VALUES ($1, $2, $3)
+		RETURNING pinned_at
+	`
+
+	var pinnedAt time.Time
+	if err := tx.QueryRow(ctx, query, messageID, chatID, userID).Scan(&pinnedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return time.Time{}, fmt.Errorf("%s: %w", op, ErrMessagePinned)
+			case "23503":
+				return time.Time{}, fmt.Errorf("%s: %w", op, ErrMessageNotFound)
+			}
+		}
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pinnedAt, nil
+}
+
+func (s *PinStorage) Unpin(ctx context.Context, messageID int64) error {
+	op := "repo.Pin.Unpin"
+
+	tag, err := s.db.Exec(ctx, `DELETE FROM pinned_messages WHERE message_id = $1`, messageID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrPinNotFound)
+	}
+
+	return nil
+}
+
+// List returns the pinned messages of a chat, most recently pinned first.
+func (s *PinStorage) List(ctx context.Context, chatID int64) ([]models.Pin, error) {
+	op := "repo.Pin.List"
+
+	query := `
+		SELECT p.pinned_by, p.pinned_at, m.*
+		FROM pinned_messages p
+		CROSS JOIN LATERAL (
+			SELECT ` + messageColumns + `
+			FROM messages
+			WHERE id = p.message_id
+		) m
+		WHERE p.chat_id = $1
+		ORDER BY p.pinned_at DESC, p.message_id DESC
+	`
+
+	rows, err := s.db.Query(ctx, query, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var pins []models.Pin
+	for rows.Next() {
+		var pin models.Pin
+		dest := append([]any{&pin.PinnedBy, &pin.PinnedAt}, messageFields(&pin.Message)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		pins = append(pins, pin)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pins, nil
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PresenceStorage struct {
+	db *pgxpool.Pool
+}
+
+func NewPresenceRepository(ctx context.Context, dbCfg *config.DBConfig) (*PresenceStorage, error) {
+	dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
+		dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Port, dbCfg.DBName)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &PresenceStorage{db: pool}, nil
+}
+
+// SetLastSeen records when the user was last seen. An earlier time than the
+// stored one is ignored, so concurrent disconnects may land in any order.
+func (s *PresenceStorage) SetLastSeen(ctx context.Context, userID int64, at time.Time) error {
+	op := "repo.Presence.SetLastSeen"
+
+	query := `
+		INSERT INTO user_presence (user_id, last_seen_at)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET last_seen_at = GREATEST(user_presence.last_seen_at, EXCLUDED.last_seen_at)
+	`
+	if _, err := s.db.Exec(ctx, query, userID, at); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// LastSeen returns the last-seen time of the given users keyed by user id.
+// Users who have never connected are left out.
+func (s *PresenceStorage) LastSeen(ctx context.Context, userIDs []int64) (map[int64]time.Time, error) {
+	op := "repo.Presence.LastSeen"
+
+	query := `
+		SELECT user_id, last_seen_at
+		FROM user_presence
+		WHERE user_id = ANY($1)
+	`
+
+	rows, err := s.db.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	lastSeen := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			userID int64
+			at     time.Time
+		)
+		if err := rows.Scan(&userID, &at); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		lastSeen[userID] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return lastSeen, nil
+}
+
+// SharingChat returns those of userIDs that are members of at least one chat
+// together with userID.
+func (s *PresenceStorage) SharingChat(ctx context.Context, userID int64, userIDs []int64) ([]int64, error) {
+	op := "repo.Presence.SharingChat"
+
+	query := `
+		SELECT DISTINCT other.user_id
+		FROM chat_members me
+		JOIN chat_members other ON other.chat_id = me.chat_id
+		WHERE me.user_id = $1 AND other.user_id = ANY($2)
+	`
+
+	rows, err := s.db.Query(ctx, query, userID, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
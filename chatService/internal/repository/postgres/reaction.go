@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Gilf4/grpcChat/chat/internal/config"
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReactionStorage struct {
+	db *pgxpool.Pool
+}
+
+func NewReactionRepository(ctx context.Context, dbCfg *config.DBConfig) (*ReactionStorage, error) {
+	dsn := fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s sslmode=disable",
+		dbCfg.User, dbCfg.Password, dbCfg.Host, dbCfg.Port, dbCfg.DBName)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return &ReactionStorage{db: pool}, nil
+}
+
+// Add records a reaction unless the message is deleted. The message row is
+// share-locked while the reaction is inserted, so a concurrent Delete either
+// commits first, and ErrMessageDeleted is returned, or waits and then clears
+// the reaction with the others.
+func (s *ReactionStorage) Add(ctx context.Context, messageID, userID int64, emoji string) error {
+	op := "repo.Reaction.Add"
+
+	query := `
+		INSERT INTO message_reactions (message_id, user_id, emoji)
+		SELECT id, $2, $3
+		FROM messages
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR SHARE
+	`
+	tag, err := s.db.Exec(ctx, query, messageID, userID, emoji)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return fmt.Errorf("%s: %w", op, ErrReactionExists)
+			case "23503":
+				return fmt.Errorf("%s: %w", op, ErrMessageNotFound)
+			}
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrMessageDeleted)
+	}
+
+	return nil
+}
+
+func (s *ReactionStorage) Remove(ctx context.Context, messageID, userID int64, emoji string) error {
+	op := "repo.Reaction.Remove"
+
+	query := `
+		DELETE FROM message_reactions
+		WHERE message_id = $1 AND user_id = $2 AND emoji = $3
+	`
+	tag, err := s.db.Exec(ctx, query, messageID, userID, emoji)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrReactionNotFound)
+	}
+
+	return nil
+}
+
+// Counts returns the aggregated reactions of the given messages keyed by
+// message id, each ordered by when the emoji was first used.
+func (s *ReactionStorage) Counts(ctx context.Context, messageIDs []int64) (map[int64][]models.ReactionCount, error) {
+	op := "repo.Reaction.Counts"
+
+	query := `
+		SELECT message_id, emoji, count(*)
+		FROM message_reactions
+		WHERE message_id = ANY($1)
+		GROUP BY message_id, emoji
+		ORDER BY message_id, min(created_at), emoji
+	`
+
+	rows, err := s.db.Query(ctx, query, messageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int64][]models.ReactionCount)
+	for rows.Next() {
+		var (
+			messageID int64
+			count     models.ReactionCount
+		)
+		if err := rows.Scan(&messageID, &count.Emoji, &count.Count); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		counts[messageID] = append(counts[messageID], count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,381 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/Gilf4/grpcChat/chat/internal/lib/imaging"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+)
+
+var (
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	ErrAttachmentType     = errors.New("attachment type is not allowed")
+	ErrAttachmentEmpty    = errors.New("attachment is empty")
+)
+
+const (
+	// sniffLength is how much of an upload http.DetectContentType looks at.
+	sniffLength = 512
+	// maxImagePixels guards thumbnail generation against decompression bombs.
+	maxImagePixels = 40_000_000
+)
+
+var (
+	// thumbnailTypes are the image types previews are generated for.
+	thumbnailTypes = []string{"image/png", "image/jpeg", "image/gif"}
+	// thumbnailSizes are the edges of the square boxes previews are fit into.
+	thumbnailSizes = []int{160, 640}
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, att models.Attachment) (models.Attachment, error)
+	Get(ctx context.Context, id int64) (models.Attachment, error)
+	GetThumbnail(ctx context.Context, id int64) (models.Thumbnail, error)
+	ListByMessages(ctx context.Context, messageIDs []int64) (map[int64][]models.Attachment, error)
+}
+
+// BlobStore keeps attachment contents under opaque keys.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type AttachmentLimits struct {
+	MaxSize int64
+	// AllowedTypes lists the accepted media types, e.g. "image/png".
+	AllowedTypes []string
+}
+
+// UploadAttachment stores the content read from r as an unsent attachment of
+// the chat. The content type is sniffed from the data rather than trusted from
+// the client. JPEG files are stored without their EXIF data apart from the
+// orientation, and PNG, JPEG and GIF images get thumbnails.
+func (c *ChatService) UploadAttachment(
+	ctx context.Context,
+	userID int64,
+	chatID int64,
+	filename string,
+	r io.Reader,
+) (models.Attachment, error) {
+	const op = "ChatService.UploadAttachment"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("chat_id", chatID),
+	)
+
+	if _, err := c.Authorize(ctx, chatID, userID, PermSendMessages); err != nil {
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if n == 0 {
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, ErrAttachmentEmpty)
+	}
+	head = head[:n]
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil || !slices.Contains(c.attachmentLimits.AllowedTypes, contentType) {
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, ErrAttachmentType)
+	}
+
+	key, err := newStorageKey()
+	if err != nil {
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The limit applies to the upload as sent, before EXIF is stripped.
+	counter := &countingReader{r: io.LimitReader(io.MultiReader(bytes.NewReader(head), r), c.attachmentLimits.MaxSize+1)}
+
+	var content io.Reader = counter
+	if contentType == "image/jpeg" {
+		pr, pw := io.Pipe()
+		defer pr.Close()
+
+		go func() {
+			pw.CloseWithError(imaging.StripEXIF(pw, counter))
+		}()
+		content = pr
+	}
+
+	size, err := c.blobs.Put(ctx, key, content)
+	if err != nil {
+		log.Error("failed to store blob", "error", err.Error())
+
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if counter.n > c.attachmentLimits.MaxSize {
+		c.deleteBlob(log, key)
+
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, ErrAttachmentTooLarge)
+	}
+
+	att := models.Attachment{
+		ChatID:      chatID,
+		UploaderID:  userID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+	}
+
+	if slices.Contains(thumbnailTypes, contentType) {
+		c.addThumbnails(ctx, log, &att)
+	}
+
+	saved, err := c.attachmentRepo.Create(ctx, att)
+	if err != nil {
+		log.Error("failed to save attachment", "error", err.Error())
+		c.deleteBlob(log, key)
+		for _, thumb := range att.Thumbnails {
+			c.deleteBlob(log, thumb.StorageKey)
+		}
+
+		return models.Attachment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return saved, nil
+}
+
+// DownloadAttachment opens the content of an attachment, or of one of its
+// thumbnails when thumbnailID is set. Unsent attachments are only visible to
+// their uploader, sent ones to readers of the chat as long as the message
+// exists. The caller must close the reader.
+func (c *ChatService) DownloadAttachment(
+	ctx context.Context,
+	userID int64,
+	attachmentID int64,
+	thumbnailID int64,
+) (models.Attachment, io.ReadCloser, error) {
+	const op = "ChatService.DownloadAttachment"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("attachment_id", attachmentID),
+		slog.Int64("thumbnail_id", thumbnailID),
+	)
+
+	key := ""
+	if thumbnailID != 0 {
+		thumb, err := c.attachmentRepo.GetThumbnail(ctx, thumbnailID)
+		if err != nil {
+			return models.Attachment{}, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if thumb.AttachmentID != attachmentID {
+			return models.Attachment{}, nil, fmt.Errorf("%s: %w", op, postgres.ErrAttachmentNotFound)
+		}
+		key = thumb.StorageKey
+	}
+
+	att, err := c.attachmentRepo.Get(ctx, attachmentID)
+	if err != nil {
+		return models.Attachment{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if att.MessageID == 0 {
+		if att.UploaderID != userID {
+			return models.Attachment{}, nil, fmt.Errorf("%s: %w", op, postgres.ErrAttachmentNotFound)
+		}
+	} else {
+		if _, err := c.Authorize(ctx, att.ChatID, userID, PermReadMessages); err != nil {
+			return models.Attachment{}, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		msg, err := c.messageRepo.Get(ctx, att.MessageID)
+		if err != nil {
+			return models.Attachment{}, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if msg.DeletedAt != nil {
+			return models.Attachment{}, nil, fmt.Errorf("%s: %w", op, postgres.ErrAttachmentNotFound)
+		}
+	}
+
+	if key == "" {
+		key = att.StorageKey
+	}
+
+	content, err := c.blobs.Get(ctx, key)
+	if err != nil {
+		log.Error("failed to open blob", "error", err.Error())
+
+		return models.Attachment{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return att, content, nil
+}
+
+// loadAttachments fills in the attachments of msgs in place. Tombstones keep
+// none.
+func (c *ChatService) loadAttachments(ctx context.Context, msgs []models.Message) error {
+	ids := make([]int64, 0, len(msgs))
+	for _, m := range msgs {
+		if m.DeletedAt == nil {
+			ids = append(ids, m.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	atts, err := c.attachmentRepo.ListByMessages(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range msgs {
+		if msgs[i].DeletedAt == nil {
+			msgs[i].Attachments = atts[msgs[i].ID]
+		}
+	}
+
+	return nil
+}
+
+// addThumbnails records the dimensions of an image attachment and stores its
+// previews. Both are upright: previews carry no EXIF, so the orientation of
+// the original is applied to their pixels. An image that cannot be decoded is
+// kept as a plain file.
+func (c *ChatService) addThumbnails(ctx context.Context, log *slog.Logger, att *models.Attachment) {
+	img, format, orientation, err := c.decodeImage(ctx, att.StorageKey)
+	if err != nil {
+		log.Warn("failed to decode image", "error", err.Error())
+		return
+	}
+
+	src := imaging.ToRGBA(img)
+	storedW, storedH := src.Bounds().Dx(), src.Bounds().Dy()
+
+	att.Width, att.Height = storedW, storedH
+	if orientation >= 5 {
+		att.Width, att.Height = storedH, storedW
+	}
+
+	var thumbs []models.Thumbnail
+	for _, box := range thumbnailSizes {
+		// Resizing before orienting keeps the rotation cheap.
+		width, height := imaging.Fit(storedW, storedH, box)
+		thumb := imaging.Orient(imaging.Resize(src, width, height), orientation)
+		width, height = thumb.Bounds().Dx(), thumb.Bounds().Dy()
+
+		var buf bytes.Buffer
+		contentType, err := imaging.Encode(&buf, thumb, format)
+		if err != nil {
+			log.Warn("failed to encode thumbnail", "error", err.Error())
+			break
+		}
+
+		key, err := newStorageKey()
+		if err != nil {
+			log.Warn("failed to store thumbnail", "error", err.Error())
+			break
+		}
+
+		size, err := c.blobs.Put(ctx, key, &buf)
+		if err != nil {
+			log.Warn("failed to store thumbnail", "error", err.Error())
+			break
+		}
+
+		thumbs = append(thumbs, models.Thumbnail{
+			Width:       width,
+			Height:      height,
+			ContentType: contentType,
+			Size:        size,
+			StorageKey:  key,
+		})
+	}
+
+	if len(thumbs) < len(thumbnailSizes) {
+		for _, thumb := range thumbs {
+			c.deleteBlob(log, thumb.StorageKey)
+		}
+		return
+	}
+
+	att.Thumbnails = thumbs
+}
+
+// decodeImage decodes a stored image and returns it together with its format
+// and EXIF orientation.
+func (c *ChatService) decodeImage(ctx context.Context, key string) (image.Image, string, int, error) {
+	header, err := c.blobs.Get(ctx, key)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	cfg, format, err := imaging.DecodeConfig(header)
+	header.Close()
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		header, err := c.blobs.Get(ctx, key)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		orientation, err = imaging.Orientation(header)
+		header.Close()
+		if err != nil {
+			return nil, "", 0, err
+		}
+	}
+
+	content, err := c.blobs.Get(ctx, key)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer content.Close()
+
+	img, err := imaging.Decode(content, cfg, maxImagePixels)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	return img, format, orientation, nil
+}
+
+func (c *ChatService) deleteBlob(log *slog.Logger, key string) {
+	if err := c.blobs.Delete(context.Background(), key); err != nil {
+		log.Error("failed to delete blob", "key", key, "error", err.Error())
+	}
+}
+
+func newStorageKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
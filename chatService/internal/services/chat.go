@@ -1,29 +1,469 @@
 package services
 
-import "github.com/Gilf4/grpcChat/chat/internal/domain/models"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+)
+
+var (
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrOwnerCannotLeave = errors.New("owner cannot leave the chat")
+	ErrInvalidRole      = errors.New("invalid role")
+	ErrDirectChat       = errors.New("not supported for direct chats")
+	ErrSelfDirectChat   = errors.New("cannot start a direct chat with yourself")
+	ErrInvalidReply     = errors.New("reply target is in another chat")
+	ErrMessageNotInChat = errors.New("message does not belong to the chat")
+)
 
 type ChatRepository interface {
-	Create(chat *models.Chat) error
-	Get(id string) (*models.Chat, error)
-	GetList() ([]*models.Chat, error)
-	Delete(id string) error
+	Create(ctx context.Context, name string, public bool, creatorID int64) (models.Chat, error)
+	GetOrCreateDirect(ctx context.Context, userID, peerID int64) (models.Chat, error)
+	Get(ctx context.Context, id int64) (models.Chat, error)
+	GetList(ctx context.Context, userID int64) ([]models.Chat, error)
+	SetRetention(ctx context.Context, id int64, retention models.Retention) error
+	Delete(ctx context.Context, id int64) error
 }
 
 type MessageRepository interface {
-	Create(message *models.Message) error
-	Get(id string) (*models.Message, error)
-	GetList() ([]*models.Message, error)
-	Delete(id string) error
+	Create(ctx context.Context, msg models.Message, attachmentIDs []int64) (models.Message, error)
+	Get(ctx context.Context, id int64) (models.Message, error)
+	GetList(ctx context.Context, chatID int64) ([]models.Message, error)
+	GetPage(ctx context.Context, chatID, cursor int64, direction models.Direction, limit int) ([]models.Message, error)
+	GetAfterSeq(ctx context.Context, chatID, seq int64, limit int) ([]models.Message, error)
+	SeqAt(ctx context.Context, chatID, messageID int64) (int64, error)
+	GetLatest(ctx context.Context, chatIDs []int64) (map[int64]models.Message, error)
+	Search(ctx context.Context, userID int64, filter models.SearchFilter, cursor int64, limit int) ([]models.SearchResult, error)
+	GetThreadPage(ctx context.Context, rootID, cursor int64, direction models.Direction, limit int) ([]models.Message, error)
+	Update(ctx context.Context, id int64, text string, mentioned []int64) (models.Message, error)
+	Delete(ctx context.Context, id int64) (models.Message, bool, []string, error)
 }
 
+type MemberRepository interface {
+	Add(ctx context.Context, chatID, userID int64, role models.Role) error
+	Remove(ctx context.Context, chatID, userID int64) error
+	Get(ctx context.Context, chatID, userID int64) (models.Member, error)
+	SetRole(ctx context.Context, chatID, userID int64, role models.Role) error
+	MarkRead(ctx context.Context, chatID, userID, messageID, seq int64) (bool, error)
+	List(ctx context.Context, chatID int64) ([]models.Member, error)
+	ChatIDs(ctx context.Context, userID int64) ([]int64, error)
+	ResolveMentions(ctx context.Context, chatID int64, handles []string, everyone bool) ([]int64, error)
+}
+
+type ReactionRepository interface {
+	Add(ctx context.Context, messageID, userID int64, emoji string) error
+	Remove(ctx context.Context, messageID, userID int64, emoji string) error
+	Counts(ctx context.Context, messageIDs []int64) (map[int64][]models.ReactionCount, error)
+}
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
 type ChatService struct {
-	chatRepo    ChatRepository
-	messageRepo MessageRepository
+	log          *slog.Logger
+	chatRepo     ChatRepository
+	messageRepo  MessageRepository
+	memberRepo   MemberRepository
+	reactionRepo ReactionRepository
+
+	attachmentRepo   AttachmentRepository
+	blobs            BlobStore
+	attachmentLimits AttachmentLimits
+	mentionRepo      MentionRepository
+	pinRepo          PinRepository
+	exportRepo       ExportRepository
+	importRepo       ImportRepository
+	// importers are the users allowed to import chats.
+	importers []int64
 }
 
-func NewChatService(chatRepo ChatRepository, messageRepo MessageRepository) *ChatService {
+func NewChatService(
+	log *slog.Logger,
+	chatRepo ChatRepository,
+	messageRepo MessageRepository,
+	memberRepo MemberRepository,
+	reactionRepo ReactionRepository,
+	attachmentRepo AttachmentRepository,
+	blobs BlobStore,
+	attachmentLimits AttachmentLimits,
+	mentionRepo MentionRepository,
+	pinRepo PinRepository,
+	exportRepo ExportRepository,
+	importRepo ImportRepository,
+	importers []int64,
+) *ChatService {
 	return &ChatService{
-		chatRepo:    chatRepo,
-		messageRepo: messageRepo,
+		log:          log,
+		chatRepo:     chatRepo,
+		messageRepo:  messageRepo,
+		memberRepo:   memberRepo,
+		reactionRepo: reactionRepo,
+
+		attachmentRepo:   attachmentRepo,
+		blobs:            blobs,
+		attachmentLimits: attachmentLimits,
+		mentionRepo:      mentionRepo,
+		pinRepo:          pinRepo,
+		exportRepo:       exportRepo,
+		importRepo:       importRepo,
+		importers:        importers,
+	}
+}
+
+func (c *ChatService) CreateChat(ctx context.Context, userID int64, name string, public bool) (models.Chat, error) {
+	const op = "ChatService.CreateChat"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.String("name", name),
+		slog.Bool("public", public),
+	)
+
+	log.Info("attempting to create chat")
+
+	chat, err := c.chatRepo.Create(ctx, name, public, userID)
+	if err != nil {
+		log.Error("failed to save chat", "error", err.Error())
+
+		return models.Chat{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return chat, nil
+}
+
+// GetOrCreateDirectChat returns the direct chat between the two users,
+// creating it on first use.
+func (c *ChatService) GetOrCreateDirectChat(ctx context.Context, userID, peerID int64) (models.Chat, error) {
+	const op = "ChatService.GetOrCreateDirectChat"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("peer_id", peerID),
+	)
+
+	if userID == peerID {
+		return models.Chat{}, fmt.Errorf("%s: %w", op, ErrSelfDirectChat)
+	}
+
+	chat, err := c.chatRepo.GetOrCreateDirect(ctx, userID, peerID)
+	if err != nil {
+		log.Warn("failed to get direct chat", "error", err.Error())
+
+		return models.Chat{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return chat, nil
+}
+
+// GetChatList returns the user's chats with their unread counts and a preview
+// of the last message.
+func (c *ChatService) GetChatList(ctx context.Context, userID int64) ([]models.Chat, error) {
+	const op = "ChatService.GetChatList"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+	)
+
+	chats, err := c.chatRepo.GetList(ctx, userID)
+	if err != nil {
+		log.Error("failed to get chats", "error", err.Error())
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ids := make([]int64, 0, len(chats))
+	for _, chat := range chats {
+		ids = append(ids, chat.ID)
+	}
+
+	latest, err := c.messageRepo.GetLatest(ctx, ids)
+	if err != nil {
+		log.Error("failed to get last messages", "error", err.Error())
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range chats {
+		if msg, ok := latest[chats[i].ID]; ok {
+			chats[i].LastMessage = &msg
+		}
+	}
+
+	return chats, nil
+}
+
+// SendMessage stores a message. A non-zero replyToID puts it into the thread
+// of that message; replies to replies join the thread of their root. The
+// attachments must have been uploaded by the sender to the same chat.
+// Members mentioned in the text are recorded with the message.
+func (c *ChatService) SendMessage(
+	ctx context.Context,
+	chatID int64,
+	senderID int64,
+	text string,
+	replyToID int64,
+	attachmentIDs []int64,
+) (models.Message, error) {
+	const op = "ChatService.SendMessage"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+		slog.Int64("sender_id", senderID),
+	)
+
+	sender, err := c.Authorize(ctx, chatID, senderID, PermSendMessages)
+	if err != nil {
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if replyToID != 0 {
+		parent, err := c.messageRepo.Get(ctx, replyToID)
+		if err != nil {
+			log.Warn("failed to get reply target", "error", err.Error())
+
+			return models.Message{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if parent.ChatID != chatID {
+			return models.Message{}, fmt.Errorf("%s: %w", op, ErrInvalidReply)
+		}
+
+		if parent.ReplyToID != 0 {
+			replyToID = parent.ReplyToID
+		}
+	}
+
+	mentioned, err := c.resolveMentions(ctx, sender, senderID, text)
+	if err != nil {
+		log.Error("failed to resolve mentions", "error", err.Error())
+
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msg, err := c.messageRepo.Create(ctx, models.Message{
+		ChatID:           chatID,
+		SenderID:         senderID,
+		Text:             text,
+		ReplyToID:        replyToID,
+		MentionedUserIDs: mentioned,
+	}, attachmentIDs)
+	if err != nil {
+		log.Error("failed to save message", "error", err.Error())
+
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(attachmentIDs) > 0 {
+		msgs := []models.Message{msg}
+		if err := c.loadAttachments(ctx, msgs); err != nil {
+			log.Error("failed to get attachments", "error", err.Error())
+
+			return models.Message{}, fmt.Errorf("%s: %w", op, err)
+		}
+		msg = msgs[0]
+	}
+
+	return msg, nil
+}
+
+// GetMessages returns a page of chat history, newest first, and the cursor of
+// the next page in the same direction. The next cursor is zero on the last page.
+func (c *ChatService) GetMessages(
+	ctx context.Context,
+	userID int64,
+	chatID int64,
+	cursor int64,
+	direction models.Direction,
+	limit int,
+) ([]models.Message, int64, error) {
+	const op = "ChatService.GetMessages"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+	)
+
+	if _, err := c.Authorize(ctx, chatID, userID, PermReadMessages); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	limit = pageLimit(limit)
+
+	msgs, err := c.messageRepo.GetPage(ctx, chatID, cursor, direction, limit+1)
+	if err != nil {
+		log.Error("failed to get messages", "error", err.Error())
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msgs, next := trimPage(msgs, limit, direction)
+
+	if err := c.attachReactions(ctx, msgs); err != nil {
+		log.Error("failed to get reactions", "error", err.Error())
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.loadAttachments(ctx, msgs); err != nil {
+		log.Error("failed to get attachments", "error", err.Error())
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msgs, next, nil
+}
+
+// ResumeSeq returns the sequence number a stream of the chat resumes after
+// for a client that last saw lastSeenID.
+func (c *ChatService) ResumeSeq(ctx context.Context, userID, chatID, lastSeenID int64) (int64, error) {
+	const op = "ChatService.ResumeSeq"
+
+	if _, err := c.Authorize(ctx, chatID, userID, PermReadMessages); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	seq, err := c.messageRepo.SeqAt(ctx, chatID, lastSeenID)
+	if err != nil {
+		c.log.Error("failed to resolve resume position",
+			slog.String("op", op),
+			slog.Int64("chat_id", chatID),
+			"error", err.Error(),
+		)
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return seq, nil
+}
+
+// MessagesAfter returns up to limit messages of the chat with a sequence
+// number above afterSeq, oldest first.
+func (c *ChatService) MessagesAfter(ctx context.Context, userID, chatID, afterSeq int64, limit int) ([]models.Message, error) {
+	const op = "ChatService.MessagesAfter"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+	)
+
+	if _, err := c.Authorize(ctx, chatID, userID, PermReadMessages); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msgs, err := c.messageRepo.GetAfterSeq(ctx, chatID, afterSeq, pageLimit(limit))
+	if err != nil {
+		log.Error("failed to get messages", "error", err.Error())
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.attachReactions(ctx, msgs); err != nil {
+		log.Error("failed to get reactions", "error", err.Error())
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.loadAttachments(ctx, msgs); err != nil {
+		log.Error("failed to get attachments", "error", err.Error())
+
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+
+	return msgs, nil
+}
+
+// GetThread returns the root of the thread messageID belongs to together with
+// a page of its replies, paged like GetMessages.
+func (c *ChatService) GetThread(
+	ctx context.Context,
+	userID int64,
+	messageID int64,
+	cursor int64,
+	direction models.Direction,
+	limit int,
+) (models.Message, []models.Message, int64, error) {
+	const op = "ChatService.GetThread"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("message_id", messageID),
+	)
+
+	root, err := c.messageRepo.Get(ctx, messageID)
+	if err != nil {
+		log.Warn("failed to get message", "error", err.Error())
+
+		return models.Message{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := c.Authorize(ctx, root.ChatID, userID, PermReadMessages); err != nil {
+		return models.Message{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if root.ReplyToID != 0 {
+		root, err = c.messageRepo.Get(ctx, root.ReplyToID)
+		if err != nil {
+			log.Error("failed to get thread root", "error", err.Error())
+
+			return models.Message{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	limit = pageLimit(limit)
+
+	replies, err := c.messageRepo.GetThreadPage(ctx, root.ID, cursor, direction, limit+1)
+	if err != nil {
+		log.Error("failed to get replies", "error", err.Error())
+
+		return models.Message{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	replies, next := trimPage(replies, limit, direction)
+
+	msgs := append([]models.Message{root}, replies...)
+	if err := c.attachReactions(ctx, msgs); err != nil {
+		log.Error("failed to get reactions", "error", err.Error())
+
+		return models.Message{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.loadAttachments(ctx, msgs); err != nil {
+		log.Error("failed to get attachments", "error", err.Error())
+
+		return models.Message{}, nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msgs[0], msgs[1:], next, nil
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+	return min(limit, maxPageSize)
+}
+
+// trimPage drops the extra message fetched to detect further pages and
+// returns the cursor of the next page, zero if there is none.
+func trimPage(msgs []models.Message, limit int, direction models.Direction) ([]models.Message, int64) {
+	if len(msgs) <= limit {
+		return msgs, 0
+	}
+
+	if direction == models.DirectionNewer {
+		msgs = msgs[1:]
+		return msgs, msgs[0].ID
+	}
+
+	msgs = msgs[:limit]
+	return msgs, msgs[limit-1].ID
 }
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/Gilf4/grpcChat/chat/internal/lib/chatexport"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/localfs"
+)
+
+// exportBatchSize is how many messages are read from the snapshot at a time.
+const exportBatchSize = 500
+
+type ExportRepository interface {
+	Export(ctx context.Context, chatID int64, batchSize int, sink models.ExportSink) error
+}
+
+// ExportChat writes the chat in the chatexport format to w. Group chats take
+// PermExportChat; either participant of a direct chat may export it.
+func (c *ChatService) ExportChat(ctx context.Context, userID, chatID int64, w io.Writer) error {
+	const op = "ChatService.ExportChat"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("chat_id", chatID),
+	)
+
+	chat, err := c.chatRepo.Get(ctx, chatID)
+	if err != nil {
+		log.Warn("failed to get chat", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	perm := PermExportChat
+	if chat.Kind == models.ChatKindDirect {
+		perm = PermReadMessages
+	}
+
+	if _, err := c.Authorize(ctx, chatID, userID, perm); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.writeExport(ctx, log, chatID, w, false); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DumpChat writes the chat in the chatexport format to w without checking
+// any permissions, for operator tooling. Unlike ExportChat it includes the
+// users' emails, which importing the chat elsewhere relies on.
+func (c *ChatService) DumpChat(ctx context.Context, chatID int64, w io.Writer) error {
+	const op = "ChatService.DumpChat"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+	)
+
+	if err := c.writeExport(ctx, log, chatID, w, true); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *ChatService) writeExport(ctx context.Context, log *slog.Logger, chatID int64, w io.Writer, withEmails bool) error {
+	sink := &exportWriter{
+		ctx:        ctx,
+		enc:        chatexport.NewEncoder(w),
+		blobs:      c.blobs,
+		withEmails: withEmails,
+	}
+	if err := c.exportRepo.Export(ctx, chatID, exportBatchSize, sink); err != nil {
+		log.Error("failed to export chat", "error", err.Error())
+
+		return err
+	}
+
+	sink.end.Type = chatexport.TypeEnd
+	if err := sink.enc.Encode(sink.end); err != nil {
+		log.Error("failed to write export", "error", err.Error())
+
+		return err
+	}
+
+	log.Info("chat exported", slog.Int("messages", sink.end.Messages))
+
+	return nil
+}
+
+// exportWriter turns a chat snapshot into chatexport lines, counting them for
+// the end line. Emails are left out unless withEmails is set.
+type exportWriter struct {
+	ctx        context.Context
+	enc        *chatexport.Encoder
+	end        chatexport.End
+	blobs      BlobStore
+	buf        []byte
+	withEmails bool
+}
+
+func (e *exportWriter) Chat(chat models.Chat, createdAt time.Time) error {
+	err := e.enc.Encode(chatexport.Header{
+		Type:       chatexport.TypeHeader,
+		Format:     chatexport.Format,
+		Version:    chatexport.Version,
+		ExportedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.enc.Encode(chatexport.Chat{
+		Type: chatexport.TypeChat,
+		ID:   chat.ID,
+		Name: chat.Name,
+		Kind: string(chat.Kind),
+		Retention: chatexport.Retention{
+			Mode:       string(chat.Retention.Mode),
+			TTLSeconds: int64(chat.Retention.TTL / time.Second),
+		},
+		CreatedAt: createdAt,
+	})
+}
+
+func (e *exportWriter) Users(users []models.User) error {
+	for _, user := range users {
+		line := chatexport.User{
+			Type: chatexport.TypeUser,
+			ID:   user.ID,
+			Name: user.Name,
+		}
+		if e.withEmails {
+			line.Email = user.Email
+		}
+
+		if err := e.enc.Encode(line); err != nil {
+			return err
+		}
+		e.end.Users++
+	}
+
+	return nil
+}
+
+func (e *exportWriter) Members(members []models.Member) error {
+	for _, member := range members {
+		err := e.enc.Encode(chatexport.Member{
+			Type:     chatexport.TypeMember,
+			UserID:   member.UserID,
+			Role:     string(member.Role),
+			JoinedAt: member.JoinedAt,
+		})
+		if err != nil {
+			return err
+		}
+		e.end.Members++
+	}
+
+	return nil
+}
+
+func (e *exportWriter) Messages(msgs []models.Message, reactions map[int64][]models.Reaction) error {
+	for _, msg := range msgs {
+		line := chatexport.Message{
+			Type:             chatexport.TypeMessage,
+			ID:               msg.ID,
+			Seq:              msg.Seq,
+			SenderID:         msg.SenderID,
+			Text:             msg.Text,
+			CreatedAt:        msg.CreatedAt,
+			EditedAt:         msg.EditedAt,
+			DeletedAt:        msg.DeletedAt,
+			ReplyToID:        msg.ReplyToID,
+			MentionedUserIDs: msg.MentionedUserIDs,
+		}
+		for _, r := range reactions[msg.ID] {
+			line.Reactions = append(line.Reactions, chatexport.Reaction{
+				UserID:    r.UserID,
+				Emoji:     r.Emoji,
+				CreatedAt: r.CreatedAt,
+			})
+		}
+		// Tombstones are exported without attachments, as DownloadAttachment
+		// hides them.
+		attachments := msg.Attachments
+		if msg.DeletedAt != nil {
+			attachments = nil
+		}
+		for i, att := range attachments {
+			// Blob lines of one key must be consecutive, so send them once.
+			written := slices.ContainsFunc(attachments[:i], func(prev models.Attachment) bool {
+				return prev.StorageKey == att.StorageKey
+			})
+			if !written {
+				if err := e.writeBlob(att.StorageKey); err != nil {
+					return err
+				}
+			}
+			line.Attachments = append(line.Attachments, chatexport.Attachment{
+				ID:          att.ID,
+				UploaderID:  att.UploaderID,
+				Filename:    att.Filename,
+				ContentType: att.ContentType,
+				Size:        att.Size,
+				Width:       att.Width,
+				Height:      att.Height,
+				StorageKey:  att.StorageKey,
+				CreatedAt:   att.CreatedAt,
+			})
+		}
+
+		if err := e.enc.Encode(line); err != nil {
+			return err
+		}
+		e.end.Messages++
+	}
+
+	return nil
+}
+
+// writeBlob sends the contents of an attachment as blob lines. A blob that is
+// gone is skipped, leaving the attachment without contents.
+func (e *exportWriter) writeBlob(key string) error {
+	r, err := e.blobs.Get(e.ctx, key)
+	if errors.Is(err, localfs.ErrBlobNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if e.buf == nil {
+		e.buf = make([]byte, chatexport.BlobChunkSize)
+	}
+
+	for {
+		n, err := io.ReadFull(r, e.buf)
+		if n > 0 {
+			line := chatexport.Blob{Type: chatexport.TypeBlob, StorageKey: key, Data: e.buf[:n]}
+			if err := e.enc.Encode(line); err != nil {
+				return err
+			}
+			e.end.Blobs++
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (e *exportWriter) Pins(pins []models.Pin) error {
+	for _, pin := range pins {
+		err := e.enc.Encode(chatexport.Pin{
+			Type:      chatexport.TypePin,
+			MessageID: pin.Message.ID,
+			PinnedBy:  pin.PinnedBy,
+			PinnedAt:  pin.PinnedAt,
+		})
+		if err != nil {
+			return err
+		}
+		e.end.Pins++
+	}
+
+	return nil
+}
@@ -0,0 +1,703 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/Gilf4/grpcChat/chat/internal/lib/chatexport"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/localfs"
+)
+
+var ErrInvalidExport = errors.New("invalid chat export")
+
+// InvalidExportError says why an export was rejected. It matches
+// ErrInvalidExport.
+type InvalidExportError struct {
+	Reason string
+}
+
+func (e *InvalidExportError) Error() string {
+	return ErrInvalidExport.Error() + ": " + e.Reason
+}
+
+func (e *InvalidExportError) Is(target error) bool {
+	return target == ErrInvalidExport
+}
+
+func invalidExport(format string, args ...any) error {
+	return &InvalidExportError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// UnknownUsersError lists, by email, the users of an export that have no
+// account in this environment.
+type UnknownUsersError struct {
+	Emails []string
+}
+
+func (e *UnknownUsersError) Error() string {
+	return "unknown users: " + strings.Join(e.Emails, ", ")
+}
+
+// importBatchSize is how many messages are committed together.
+const importBatchSize = 500
+
+type ImportRepository interface {
+	UserIDsByEmail(ctx context.Context, emails []string) (map[string]int64, error)
+	Begin(ctx context.Context, imp models.ChatImport) (int64, bool, error)
+	ImportedIDs(ctx context.Context, chatID int64, sourceIDs []int64) (map[int64]int64, error)
+	ImportMessages(ctx context.Context, chatID int64, msgs []models.ImportedMessage) error
+	Complete(ctx context.Context, chatID int64, members []models.Member, pins []models.ImportedPin) error
+}
+
+// ImportChat reads a chat in the chatexport format from r and recreates it
+// as a new chat. Users are matched by email and must all exist here, so
+// exports without emails are rejected. Messages keep their timestamps and
+// order but get new ids; attachments get new blobs from the blob lines of the
+// export. Progress is committed in batches and remembered per source chat, so
+// after a failure the same export can simply be sent again; the chat only gets its members once the whole
+// export went through. Only the configured importers may import, as an
+// import speaks for every user in it.
+func (c *ChatService) ImportChat(ctx context.Context, userID int64, r io.Reader) (models.ImportResult, error) {
+	const op = "ChatService.ImportChat"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+	)
+
+	if !slices.Contains(c.importers, userID) {
+		return models.ImportResult{}, fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+	}
+
+	imp := &chatImporter{
+		c:      c,
+		log:    log,
+		dec:    chatexport.NewDecoder(r),
+		userID: userID,
+		blobs:  make(map[string]storedBlob),
+	}
+	defer imp.discardBlobs()
+
+	res, err := imp.run(ctx)
+	if err != nil {
+		log.Warn("failed to import chat", "error", err.Error())
+
+		return models.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("chat imported",
+		slog.Int64("chat_id", res.Chat.ID),
+		slog.Int("imported", res.Imported),
+		slog.Int("skipped", res.Skipped),
+	)
+
+	return res, nil
+}
+
+// chatImporter holds the state of a single ImportChat call.
+type chatImporter struct {
+	c      *ChatService
+	log    *slog.Logger
+	dec    *chatexport.Decoder
+	userID int64
+
+	chat    chatexport.Chat
+	users   []chatexport.User
+	members []chatexport.Member
+	pins    []models.ImportedPin
+
+	// started is set once the chat exists and user ids are mapped.
+	started bool
+	userIDs map[int64]int64
+	batch   []chatexport.Message
+	lastSeq int64
+	seen    int
+
+	// upload is the blob being stored from the blob lines read so far, and
+	// blobs the stored ones no attachment took yet, by source storage key.
+	upload    *blobUpload
+	blobs     map[string]storedBlob
+	blobLines int
+
+	res models.ImportResult
+}
+
+func (imp *chatImporter) run(ctx context.Context) (models.ImportResult, error) {
+	var header chatexport.Header
+	if err := imp.expect(chatexport.TypeHeader, &header); err != nil {
+		return models.ImportResult{}, err
+	}
+	if header.Format != chatexport.Format || header.Version != chatexport.Version {
+		return models.ImportResult{}, invalidExport("unsupported format %s v%d", header.Format, header.Version)
+	}
+
+	if err := imp.expect(chatexport.TypeChat, &imp.chat); err != nil {
+		return models.ImportResult{}, err
+	}
+
+	for {
+		typ, err := imp.dec.Next()
+		if errors.Is(err, io.EOF) {
+			return models.ImportResult{}, invalidExport("truncated, no end line")
+		}
+		if err != nil {
+			return models.ImportResult{}, invalidExport("%v", err)
+		}
+
+		if typ != chatexport.TypeBlob {
+			if err := imp.endUpload(); err != nil {
+				return models.ImportResult{}, err
+			}
+		}
+
+		switch typ {
+		case chatexport.TypeUser, chatexport.TypeMember:
+			if imp.started {
+				return models.ImportResult{}, invalidExport("%s line after messages", typ)
+			}
+			if err := imp.readPerson(typ); err != nil {
+				return models.ImportResult{}, err
+			}
+
+		case chatexport.TypeBlob:
+			var blob chatexport.Blob
+			if err := imp.dec.Decode(&blob); err != nil {
+				return models.ImportResult{}, invalidExport("%v", err)
+			}
+			imp.blobLines++
+
+			if err := imp.start(ctx); err != nil {
+				return models.ImportResult{}, err
+			}
+			if imp.res.AlreadyImported {
+				return imp.res, nil
+			}
+
+			if err := imp.writeBlob(ctx, blob); err != nil {
+				return models.ImportResult{}, err
+			}
+
+		case chatexport.TypeMessage:
+			var msg chatexport.Message
+			if err := imp.dec.Decode(&msg); err != nil {
+				return models.ImportResult{}, invalidExport("%v", err)
+			}
+			if msg.Seq <= imp.lastSeq {
+				return models.ImportResult{}, invalidExport("messages out of order at seq %d", msg.Seq)
+			}
+			imp.lastSeq = msg.Seq
+			imp.seen++
+
+			if err := imp.start(ctx); err != nil {
+				return models.ImportResult{}, err
+			}
+			if imp.res.AlreadyImported {
+				return imp.res, nil
+			}
+
+			imp.batch = append(imp.batch, msg)
+			if len(imp.batch) == importBatchSize {
+				if err := imp.flush(ctx); err != nil {
+					return models.ImportResult{}, err
+				}
+			}
+
+		case chatexport.TypePin:
+			var pin chatexport.Pin
+			if err := imp.dec.Decode(&pin); err != nil {
+				return models.ImportResult{}, invalidExport("%v", err)
+			}
+			imp.pins = append(imp.pins, models.ImportedPin{
+				SourceMessageID: pin.MessageID,
+				PinnedBy:        pin.PinnedBy,
+				PinnedAt:        pin.PinnedAt,
+			})
+
+		case chatexport.TypeEnd:
+			var end chatexport.End
+			if err := imp.dec.Decode(&end); err != nil {
+				return models.ImportResult{}, invalidExport("%v", err)
+			}
+			return imp.finish(ctx, end)
+		}
+	}
+}
+
+// expect reads the next line, which must be of type typ, into v.
+func (imp *chatImporter) expect(typ string, v any) error {
+	got, err := imp.dec.Next()
+	if err != nil {
+		return invalidExport("%v", err)
+	}
+	if got != typ {
+		return invalidExport("expected %s line, got %s", typ, got)
+	}
+	if err := imp.dec.Decode(v); err != nil {
+		return invalidExport("%v", err)
+	}
+	return nil
+}
+
+func (imp *chatImporter) readPerson(typ string) error {
+	if typ == chatexport.TypeUser {
+		var user chatexport.User
+		if err := imp.dec.Decode(&user); err != nil {
+			return invalidExport("%v", err)
+		}
+		// Users are matched by email, which only operator dumps carry.
+		if user.Email == "" {
+			return invalidExport("user %d has no email", user.ID)
+		}
+		imp.users = append(imp.users, user)
+		return nil
+	}
+
+	var member chatexport.Member
+	if err := imp.dec.Decode(&member); err != nil {
+		return invalidExport("%v", err)
+	}
+	switch models.Role(member.Role) {
+	case models.RoleOwner, models.RoleAdmin, models.RoleMember, models.RoleReadOnly:
+	default:
+		return invalidExport("invalid role %q", member.Role)
+	}
+	imp.members = append(imp.members, member)
+	return nil
+}
+
+// start maps the users of the export and creates the chat, or finds the one
+// an earlier run created. It does nothing after the first call.
+func (imp *chatImporter) start(ctx context.Context) error {
+	if imp.started {
+		return nil
+	}
+	imp.started = true
+
+	emails := make([]string, 0, len(imp.users))
+	for _, user := range imp.users {
+		emails = append(emails, user.Email)
+	}
+
+	ids, err := imp.c.importRepo.UserIDsByEmail(ctx, emails)
+	if err != nil {
+		return err
+	}
+
+	imp.userIDs = make(map[int64]int64, len(imp.users))
+	var unknown []string
+	for _, user := range imp.users {
+		id, ok := ids[strings.ToLower(user.Email)]
+		if !ok {
+			unknown = append(unknown, user.Email)
+			continue
+		}
+		imp.userIDs[user.ID] = id
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return &UnknownUsersError{Emails: unknown}
+	}
+
+	chat := models.Chat{
+		Name: imp.chat.Name,
+		Kind: models.ChatKind(imp.chat.Kind),
+		Retention: models.Retention{
+			Mode: models.RetentionMode(imp.chat.Retention.Mode),
+			TTL:  time.Duration(imp.chat.Retention.TTLSeconds) * time.Second,
+		},
+	}
+	if err := ValidateRetention(chat.Retention); err != nil {
+		return invalidExport("%v", err)
+	}
+
+	memberIDs := make([]int64, 0, len(imp.members))
+	for _, member := range imp.members {
+		id, err := imp.mapUser(member.UserID)
+		if err != nil {
+			return err
+		}
+		memberIDs = append(memberIDs, id)
+	}
+
+	switch chat.Kind {
+	case models.ChatKindGroup:
+	case models.ChatKindDirect:
+		if len(memberIDs) != 2 || memberIDs[0] == memberIDs[1] {
+			return invalidExport("a direct chat needs two members")
+		}
+	default:
+		return invalidExport("invalid chat kind %q", imp.chat.Kind)
+	}
+
+	chatID, completed, err := imp.c.importRepo.Begin(ctx, models.ChatImport{
+		SourceChatID:    imp.chat.ID,
+		SourceCreatedAt: imp.chat.CreatedAt,
+		ImportedBy:      imp.userID,
+		Chat:            chat,
+		DirectUserIDs:   memberIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	chat.ID = chatID
+	imp.res.Chat = chat
+	imp.res.MemberIDs = memberIDs
+	imp.res.AlreadyImported = completed
+
+	return nil
+}
+
+func (imp *chatImporter) mapUser(sourceID int64) (int64, error) {
+	id, ok := imp.userIDs[sourceID]
+	if !ok {
+		return 0, invalidExport("user %d has no user line", sourceID)
+	}
+	return id, nil
+}
+
+// flush imports the buffered messages an earlier run did not get to.
+func (imp *chatImporter) flush(ctx context.Context) error {
+	if len(imp.batch) == 0 {
+		return nil
+	}
+
+	sourceIDs := make([]int64, 0, len(imp.batch))
+	for _, msg := range imp.batch {
+		sourceIDs = append(sourceIDs, msg.ID)
+	}
+
+	done, err := imp.c.importRepo.ImportedIDs(ctx, imp.res.Chat.ID, sourceIDs)
+	if err != nil {
+		return err
+	}
+
+	var msgs []models.ImportedMessage
+	for _, line := range imp.batch {
+		if _, ok := done[line.ID]; ok {
+			imp.res.Skipped++
+			continue
+		}
+
+		msg, err := imp.message(ctx, line)
+		if err != nil {
+			imp.deleteBlobs(msgs)
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	imp.batch = imp.batch[:0]
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	if err := imp.c.importRepo.ImportMessages(ctx, imp.res.Chat.ID, msgs); err != nil {
+		imp.deleteBlobs(msgs)
+		return err
+	}
+
+	imp.res.Imported += len(msgs)
+
+	return nil
+}
+
+// message maps a message line to this environment, copying the blobs of its
+// attachments. Attachments whose blob is not available here are dropped.
+func (imp *chatImporter) message(ctx context.Context, line chatexport.Message) (models.ImportedMessage, error) {
+	msg := models.ImportedMessage{
+		SourceID:        line.ID,
+		SourceReplyToID: line.ReplyToID,
+		Message: models.Message{
+			Text:      line.Text,
+			CreatedAt: line.CreatedAt,
+			EditedAt:  line.EditedAt,
+			DeletedAt: line.DeletedAt,
+		},
+	}
+
+	var err error
+	if msg.Message.SenderID, err = imp.mapUser(line.SenderID); err != nil {
+		return models.ImportedMessage{}, err
+	}
+
+	for _, sourceID := range line.MentionedUserIDs {
+		id, err := imp.mapUser(sourceID)
+		if err != nil {
+			return models.ImportedMessage{}, err
+		}
+		msg.Message.MentionedUserIDs = append(msg.Message.MentionedUserIDs, id)
+	}
+
+	for _, r := range line.Reactions {
+		id, err := imp.mapUser(r.UserID)
+		if err != nil {
+			return models.ImportedMessage{}, err
+		}
+		msg.Reactions = append(msg.Reactions, models.Reaction{UserID: id, Emoji: r.Emoji, CreatedAt: r.CreatedAt})
+	}
+
+	for _, a := range line.Attachments {
+		uploaderID, err := imp.mapUser(a.UploaderID)
+		if err != nil {
+			imp.deleteBlobs([]models.ImportedMessage{msg})
+			return models.ImportedMessage{}, err
+		}
+
+		att, ok, err := imp.copyAttachment(ctx, a, uploaderID)
+		if err != nil {
+			imp.deleteBlobs([]models.ImportedMessage{msg})
+			return models.ImportedMessage{}, err
+		}
+		if !ok {
+			imp.res.MissingAttachments++
+			continue
+		}
+		msg.Message.Attachments = append(msg.Message.Attachments, att)
+	}
+
+	return msg, nil
+}
+
+// copyAttachment gives an attachment line a blob of its own: the one stored
+// from its blob lines or, for exports without them, a copy of the blob with
+// its storage key in this environment. It reports false when neither exists.
+func (imp *chatImporter) copyAttachment(
+	ctx context.Context,
+	line chatexport.Attachment,
+	uploaderID int64,
+) (models.Attachment, bool, error) {
+	blob, ok := imp.blobs[line.StorageKey]
+	if ok {
+		delete(imp.blobs, line.StorageKey)
+	} else {
+		var err error
+		if blob, ok, err = imp.copyLocalBlob(ctx, line.StorageKey); err != nil || !ok {
+			return models.Attachment{}, false, err
+		}
+	}
+
+	key, size := blob.key, blob.size
+
+	att := models.Attachment{
+		UploaderID:  uploaderID,
+		Filename:    line.Filename,
+		ContentType: line.ContentType,
+		Size:        size,
+		StorageKey:  key,
+		CreatedAt:   line.CreatedAt,
+		Width:       line.Width,
+		Height:      line.Height,
+	}
+
+	if slices.Contains(thumbnailTypes, att.ContentType) {
+		imp.c.addThumbnails(ctx, imp.log, &att)
+	}
+
+	return att, true, nil
+}
+
+// copyLocalBlob copies the blob stored under key here to a new key.
+func (imp *chatImporter) copyLocalBlob(ctx context.Context, key string) (storedBlob, bool, error) {
+	if !isStorageKey(key) {
+		return storedBlob{}, false, nil
+	}
+
+	src, err := imp.c.blobs.Get(ctx, key)
+	if errors.Is(err, localfs.ErrBlobNotFound) {
+		return storedBlob{}, false, nil
+	}
+	if err != nil {
+		return storedBlob{}, false, err
+	}
+	defer src.Close()
+
+	blob := storedBlob{}
+	if blob.key, err = newStorageKey(); err != nil {
+		return storedBlob{}, false, err
+	}
+
+	if blob.size, err = imp.c.blobs.Put(ctx, blob.key, src); err != nil {
+		return storedBlob{}, false, err
+	}
+
+	return blob, true, nil
+}
+
+// storedBlob is a blob the import stored under a new key.
+type storedBlob struct {
+	key  string
+	size int64
+}
+
+// blobUpload streams the blob lines of one attachment into the blob store.
+type blobUpload struct {
+	sourceKey string
+	key       string
+	w         *io.PipeWriter
+	done      chan struct{}
+	size      int64
+	err       error
+}
+
+// writeBlob adds a blob line to the upload of its attachment, starting the
+// upload with the attachment's first line.
+func (imp *chatImporter) writeBlob(ctx context.Context, line chatexport.Blob) error {
+	if imp.upload != nil && imp.upload.sourceKey != line.StorageKey {
+		if err := imp.endUpload(); err != nil {
+			return err
+		}
+	}
+
+	if imp.upload == nil {
+		// A message that was skipped left its blob behind.
+		if blob, ok := imp.blobs[line.StorageKey]; ok {
+			imp.c.deleteBlob(imp.log, blob.key)
+			delete(imp.blobs, line.StorageKey)
+		}
+
+		key, err := newStorageKey()
+		if err != nil {
+			return err
+		}
+
+		pr, pw := io.Pipe()
+		upload := &blobUpload{sourceKey: line.StorageKey, key: key, w: pw, done: make(chan struct{})}
+		go func() {
+			defer close(upload.done)
+			upload.size, upload.err = imp.c.blobs.Put(ctx, key, pr)
+			// Unblock the writer if Put gave up before the end.
+			pr.CloseWithError(io.ErrClosedPipe)
+		}()
+		imp.upload = upload
+	}
+
+	if _, err := imp.upload.w.Write(line.Data); err != nil {
+		return imp.abortUpload(err)
+	}
+
+	return nil
+}
+
+// endUpload waits for the blob being uploaded to be stored, if any.
+func (imp *chatImporter) endUpload() error {
+	upload := imp.upload
+	if upload == nil {
+		return nil
+	}
+	imp.upload = nil
+
+	upload.w.Close()
+	<-upload.done
+	if upload.err != nil {
+		imp.c.deleteBlob(imp.log, upload.key)
+		return upload.err
+	}
+
+	imp.blobs[upload.sourceKey] = storedBlob{key: upload.key, size: upload.size}
+
+	return nil
+}
+
+// abortUpload stops the blob being uploaded and returns the reason to stop,
+// preferring the error the blob store gave.
+func (imp *chatImporter) abortUpload(err error) error {
+	upload := imp.upload
+	imp.upload = nil
+
+	upload.w.CloseWithError(err)
+	<-upload.done
+	imp.c.deleteBlob(imp.log, upload.key)
+
+	if upload.err != nil {
+		return upload.err
+	}
+	return err
+}
+
+// discardBlobs deletes the blobs the import stored that no attachment took:
+// those of messages an earlier run imported and everything stored before an
+// import failed.
+func (imp *chatImporter) discardBlobs() {
+	if imp.upload != nil {
+		imp.abortUpload(context.Canceled)
+	}
+
+	for sourceKey, blob := range imp.blobs {
+		imp.c.deleteBlob(imp.log, blob.key)
+		delete(imp.blobs, sourceKey)
+	}
+}
+
+// deleteBlobs removes the copied blobs of messages that were not stored.
+func (imp *chatImporter) deleteBlobs(msgs []models.ImportedMessage) {
+	for _, msg := range msgs {
+		for _, att := range msg.Message.Attachments {
+			imp.c.deleteBlob(imp.log, att.StorageKey)
+			for _, thumb := range att.Thumbnails {
+				imp.c.deleteBlob(imp.log, thumb.StorageKey)
+			}
+		}
+	}
+}
+
+// finish imports what is left once the end line arrived, checks the export
+// was complete and gives the chat its members and pins.
+func (imp *chatImporter) finish(ctx context.Context, end chatexport.End) (models.ImportResult, error) {
+	if end.Users != len(imp.users) || end.Members != len(imp.members) ||
+		end.Blobs != imp.blobLines || end.Messages != imp.seen || end.Pins != len(imp.pins) {
+		return models.ImportResult{}, invalidExport("end line does not match the content")
+	}
+
+	if err := imp.start(ctx); err != nil {
+		return models.ImportResult{}, err
+	}
+	if imp.res.AlreadyImported {
+		return imp.res, nil
+	}
+
+	if err := imp.flush(ctx); err != nil {
+		return models.ImportResult{}, err
+	}
+
+	members := make([]models.Member, 0, len(imp.members))
+	for i, member := range imp.members {
+		members = append(members, models.Member{
+			UserID:   imp.res.MemberIDs[i],
+			Role:     models.Role(member.Role),
+			JoinedAt: member.JoinedAt,
+		})
+	}
+
+	for i := range imp.pins {
+		id, err := imp.mapUser(imp.pins[i].PinnedBy)
+		if err != nil {
+			return models.ImportResult{}, err
+		}
+		imp.pins[i].PinnedBy = id
+	}
+
+	if err := imp.c.importRepo.Complete(ctx, imp.res.Chat.ID, members, imp.pins); err != nil {
+		return models.ImportResult{}, err
+	}
+
+	return imp.res, nil
+}
+
+// isStorageKey reports whether key has the shape newStorageKey produces, so
+// that keys from an export never reach the blob store unchecked.
+func isStorageKey(key string) bool {
+	if len(key) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
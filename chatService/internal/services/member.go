@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+)
+
+// AddMember adds userID to the chat as a regular member on behalf of callerID.
+func (c *ChatService) AddMember(ctx context.Context, callerID, chatID, userID int64) error {
+	const op = "ChatService.AddMember"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+		slog.Int64("user_id", userID),
+	)
+
+	if _, err := c.Authorize(ctx, chatID, callerID, PermManageMembers); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.memberRepo.Add(ctx, chatID, userID, models.RoleMember); err != nil {
+		log.Warn("failed to add member", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RemoveMember removes userID from the chat on behalf of callerID, who must
+// outrank the member being removed.
+func (c *ChatService) RemoveMember(ctx context.Context, callerID, chatID, userID int64) error {
+	const op = "ChatService.RemoveMember"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+		slog.Int64("user_id", userID),
+	)
+
+	caller, err := c.Authorize(ctx, chatID, callerID, PermManageMembers)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	target, err := c.memberRepo.Get(ctx, chatID, userID)
+	if err != nil {
+		log.Warn("failed to get member", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !caller.Role.Outranks(target.Role) {
+		return fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+	}
+
+	if err := c.memberRepo.Remove(ctx, chatID, userID); err != nil {
+		log.Warn("failed to remove member", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetMemberRole changes the role of userID on behalf of callerID. Ownership
+// cannot be granted or taken away this way.
+func (c *ChatService) SetMemberRole(ctx context.Context, callerID, chatID, userID int64, role models.Role) error {
+	const op = "ChatService.SetMemberRole"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+		slog.Int64("user_id", userID),
+		slog.String("role", string(role)),
+	)
+
+	switch role {
+	case models.RoleAdmin, models.RoleMember, models.RoleReadOnly:
+	default:
+		return fmt.Errorf("%s: %w", op, ErrInvalidRole)
+	}
+
+	caller, err := c.Authorize(ctx, chatID, callerID, PermManageRoles)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	target, err := c.memberRepo.Get(ctx, chatID, userID)
+	if err != nil {
+		log.Warn("failed to get member", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !caller.Role.Outranks(target.Role) {
+		return fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+	}
+
+	if err := c.memberRepo.SetRole(ctx, chatID, userID, role); err != nil {
+		log.Error("failed to set role", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("member role changed")
+
+	return nil
+}
+
+// JoinChat adds the user to a public group chat as a regular member.
+func (c *ChatService) JoinChat(ctx context.Context, userID, chatID int64) error {
+	const op = "ChatService.JoinChat"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+		slog.Int64("user_id", userID),
+	)
+
+	chat, err := c.chatRepo.Get(ctx, chatID)
+	if err != nil {
+		log.Warn("failed to get chat", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if chat.Kind == models.ChatKindDirect {
+		return fmt.Errorf("%s: %w", op, ErrDirectChat)
+	}
+
+	if !chat.Public {
+		return fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+	}
+
+	if err := c.memberRepo.Add(ctx, chatID, userID, models.RoleMember); err != nil {
+		log.Warn("failed to join chat", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *ChatService) LeaveChat(ctx context.Context, userID, chatID int64) error {
+	const op = "ChatService.LeaveChat"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", chatID),
+		slog.Int64("user_id", userID),
+	)
+
+	member, err := c.memberRepo.Get(ctx, chatID, userID)
+	if err != nil {
+		log.Warn("failed to get member", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if member.Role == models.RoleOwner {
+		return fmt.Errorf("%s: %w", op, ErrOwnerCannotLeave)
+	}
+
+	chat, err := c.chatRepo.Get(ctx, chatID)
+	if err != nil {
+		log.Error("failed to get chat", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if chat.Kind == models.ChatKindDirect {
+		return fmt.Errorf("%s: %w", op, ErrDirectChat)
+	}
+
+	if err := c.memberRepo.Remove(ctx, chatID, userID); err != nil {
+		log.Warn("failed to leave chat", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *ChatService) ListMembers(ctx context.Context, callerID, chatID int64) ([]models.Member, error) {
+	const op = "ChatService.ListMembers"
+
+	if _, err := c.Authorize(ctx, chatID, callerID, PermViewMembers); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	members, err := c.memberRepo.List(ctx, chatID)
+	if err != nil {
+		c.log.Error("failed to list members",
+			slog.String("op", op),
+			slog.Int64("chat_id", chatID),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return members, nil
+}
+
+// ChatIDs returns the ids of the chats the user belongs to.
+func (c *ChatService) ChatIDs(ctx context.Context, userID int64) ([]int64, error) {
+	const op = "ChatService.ChatIDs"
+
+	ids, err := c.memberRepo.ChatIDs(ctx, userID)
+	if err != nil {
+		c.log.Error("failed to list user chats",
+			slog.String("op", op),
+			slog.Int64("user_id", userID),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+)
+
+const (
+	mentionEveryone = "everyone"
+	maxHandleLength = 32
+)
+
+var ErrInvalidHandle = errors.New("invalid handle")
+
+// mentionPattern matches @handle where the @ does not continue a word, so
+// e-mail addresses are not taken for mentions. Handles are compared
+// case-insensitively.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\pL\pN_@])@([\pL\pN_][\pL\pN_.\-]*)`)
+
+// handlePattern is what a handle may look like: anything mentionPattern takes
+// for a whole handle, so no trailing dot.
+var handlePattern = regexp.MustCompile(`^[\pL\pN_](?:[\pL\pN_.\-]*[\pL\pN_\-])?$`)
+
+type MentionRepository interface {
+	List(ctx context.Context, userID, cursor int64, unreadOnly bool, limit int) ([]models.Notification, error)
+	MarkRead(ctx context.Context, id, userID int64) error
+	SetHandle(ctx context.Context, userID int64, handle string) error
+}
+
+// parseMentions returns the distinct lowercased handles mentioned in text and
+// whether @everyone was used.
+func parseMentions(text string) ([]string, bool) {
+	var (
+		handles  []string
+		everyone bool
+	)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		// Trailing dots end the sentence rather than the handle.
+		handle := strings.ToLower(strings.TrimRight(match[1], "."))
+		if handle == mentionEveryone {
+			everyone = true
+			continue
+		}
+		if !slices.Contains(handles, handle) {
+			handles = append(handles, handle)
+		}
+	}
+	return handles, everyone
+}
+
+// resolveMentions returns the ids of the members mentioned in text, leaving out
+// the sender of the message. @everyone only counts if the role of author, who
+// sends or edits the text, may use it.
+func (c *ChatService) resolveMentions(ctx context.Context, author models.Member, senderID int64, text string) ([]int64, error) {
+	handles, everyone := parseMentions(text)
+	everyone = everyone && Can(author.Role, PermMentionEveryone)
+	if len(handles) == 0 && !everyone {
+		return nil, nil
+	}
+
+	ids, err := c.memberRepo.ResolveMentions(ctx, author.ChatID, handles, everyone)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(ids, func(id int64) bool { return id == senderID }), nil
+}
+
+// SetHandle sets the handle the user is mentioned by. Handles are stored
+// lowercased, so they are unique regardless of case.
+func (c *ChatService) SetHandle(ctx context.Context, userID int64, handle string) error {
+	const op = "ChatService.SetHandle"
+
+	handle = strings.ToLower(handle)
+	if utf8.RuneCountInString(handle) > maxHandleLength || !handlePattern.MatchString(handle) || handle == mentionEveryone {
+		return fmt.Errorf("%s: %w", op, ErrInvalidHandle)
+	}
+
+	if err := c.mentionRepo.SetHandle(ctx, userID, handle); err != nil {
+		c.log.Warn("failed to set handle",
+			slog.String("op", op),
+			slog.Int64("user_id", userID),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListNotifications returns a page of the user's mention inbox, newest first,
+// and the cursor of the next page, zero on the last page.
+func (c *ChatService) ListNotifications(
+	ctx context.Context,
+	userID int64,
+	cursor int64,
+	unreadOnly bool,
+	limit int,
+) ([]models.Notification, int64, error) {
+	const op = "ChatService.ListNotifications"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+	)
+
+	limit = pageLimit(limit)
+
+	notifications, err := c.mentionRepo.List(ctx, userID, cursor, unreadOnly, limit+1)
+	if err != nil {
+		log.Error("failed to list notifications", "error", err.Error())
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var next int64
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		next = notifications[limit-1].ID
+	}
+
+	msgs := make([]models.Message, 0, len(notifications))
+	for _, n := range notifications {
+		msgs = append(msgs, n.Message)
+	}
+	if err := c.loadAttachments(ctx, msgs); err != nil {
+		log.Error("failed to get attachments", "error", err.Error())
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	for i := range notifications {
+		notifications[i].Message = msgs[i]
+	}
+
+	return notifications, next, nil
+}
+
+func (c *ChatService) MarkNotificationRead(ctx context.Context, userID, notificationID int64) error {
+	const op = "ChatService.MarkNotificationRead"
+
+	if err := c.mentionRepo.MarkRead(ctx, notificationID, userID); err != nil {
+		c.log.Warn("failed to mark notification read",
+			slog.String("op", op),
+			slog.Int64("user_id", userID),
+			slog.Int64("notification_id", notificationID),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
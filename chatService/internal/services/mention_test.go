@@ -0,0 +1,43 @@
+package services
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantHandles  []string
+		wantEveryone bool
+	}{
+		{name: "no mentions", text: "hello there"},
+		{name: "single", text: "@alice hi", wantHandles: []string{"alice"}},
+		{name: "inside text", text: "ping @bob, please", wantHandles: []string{"bob"}},
+		{name: "lowercased and deduplicated", text: "@Alice @alice @ALICE", wantHandles: []string{"alice"}},
+		{name: "order of first use", text: "@carol then @bob then @carol", wantHandles: []string{"carol", "bob"}},
+		{name: "trailing dot ends the sentence", text: "thanks @dave.", wantHandles: []string{"dave"}},
+		{name: "inner dots and dashes", text: "@j.doe-2 and @under_score", wantHandles: []string{"j.doe-2", "under_score"}},
+		{name: "e-mail address", text: "write to mail@example.com"},
+		{name: "double at", text: "@@alice"},
+		{name: "lone at", text: "meet @ noon"},
+		{name: "non latin", text: "привет @Юля", wantHandles: []string{"юля"}},
+		{name: "everyone", text: "@everyone standup", wantEveryone: true},
+		{name: "everyone in any case", text: "@Everyone", wantEveryone: true},
+		{name: "everyone and handles", text: "@everyone and @eve", wantHandles: []string{"eve"}, wantEveryone: true},
+		{name: "longer than everyone", text: "@everyones", wantHandles: []string{"everyones"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handles, everyone := parseMentions(tt.text)
+			if !slices.Equal(handles, tt.wantHandles) {
+				t.Errorf("parseMentions(%q) handles = %q, want %q", tt.text, handles, tt.wantHandles)
+			}
+			if everyone != tt.wantEveryone {
+				t.Errorf("parseMentions(%q) everyone = %v, want %v", tt.text, everyone, tt.wantEveryone)
+			}
+		})
+	}
+}
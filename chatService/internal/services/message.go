@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+)
+
+// EditMessage replaces the text of a message and the mentions parsed from it.
+// Authors may edit their own messages while they can still send; chat admins
+// may edit any message. It also returns the users the edit newly mentions.
+func (c *ChatService) EditMessage(ctx context.Context, userID, messageID int64, text string) (models.Message, []int64, error) {
+	const op = "ChatService.EditMessage"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("message_id", messageID),
+	)
+
+	msg, err := c.messageRepo.Get(ctx, messageID)
+	if err != nil {
+		log.Warn("failed to get message", "error", err.Error())
+
+		return models.Message{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	perm := PermEditAnyMessage
+	if msg.SenderID == userID {
+		perm = PermSendMessages
+	}
+
+	editor, err := c.Authorize(ctx, msg.ChatID, userID, perm)
+	if err != nil {
+		return models.Message{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	mentioned, err := c.resolveMentions(ctx, editor, msg.SenderID, text)
+	if err != nil {
+		log.Error("failed to resolve mentions", "error", err.Error())
+
+		return models.Message{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	added := slices.DeleteFunc(slices.Clone(mentioned), func(id int64) bool {
+		return slices.Contains(msg.MentionedUserIDs, id)
+	})
+
+	msg, err = c.messageRepo.Update(ctx, messageID, text, mentioned)
+	if err != nil {
+		log.Warn("failed to update message", "error", err.Error())
+
+		return models.Message{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msgs := []models.Message{msg}
+	if err := c.attachReactions(ctx, msgs); err != nil {
+		log.Error("failed to get reactions", "error", err.Error())
+
+		return models.Message{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.loadAttachments(ctx, msgs); err != nil {
+		log.Error("failed to get attachments", "error", err.Error())
+
+		return models.Message{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msgs[0], added, nil
+}
+
+// DeleteMessage replaces a message with a tombstone and removes its
+// attachments. Authors may delete their own messages; chat admins may delete
+// any message. It also reports whether the deletion unpinned the message.
+func (c *ChatService) DeleteMessage(ctx context.Context, userID, messageID int64) (models.Message, bool, error) {
+	const op = "ChatService.DeleteMessage"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("message_id", messageID),
+	)
+
+	msg, err := c.messageRepo.Get(ctx, messageID)
+	if err != nil {
+		log.Warn("failed to get message", "error", err.Error())
+
+		return models.Message{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	perm := PermDeleteAnyMessage
+	if msg.SenderID == userID {
+		perm = PermReadMessages
+	}
+
+	if _, err := c.Authorize(ctx, msg.ChatID, userID, perm); err != nil {
+		return models.Message{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msg, unpinned, keys, err := c.messageRepo.Delete(ctx, messageID)
+	if err != nil {
+		log.Warn("failed to delete message", "error", err.Error())
+
+		return models.Message{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, key := range keys {
+		c.deleteBlob(log, key)
+	}
+
+	log.Info("message deleted")
+
+	return msg, unpinned, nil
+}
+
+// MarkRead moves the user's read cursor in the chat forward to messageID. The
+// returned flag is false when the cursor was already there or further ahead.
+func (c *ChatService) MarkRead(ctx context.Context, userID, chatID, messageID int64) (models.ReadReceipt, bool, error) {
+	const op = "ChatService.MarkRead"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("chat_id", chatID),
+		slog.Int64("message_id", messageID),
+	)
+
+	if _, err := c.Authorize(ctx, chatID, userID, PermReadMessages); err != nil {
+		return models.ReadReceipt{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msg, err := c.messageRepo.Get(ctx, messageID)
+	if err != nil {
+		log.Warn("failed to get message", "error", err.Error())
+
+		return models.ReadReceipt{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if msg.ChatID != chatID {
+		return models.ReadReceipt{}, false, fmt.Errorf("%s: %w", op, ErrMessageNotInChat)
+	}
+
+	advanced, err := c.memberRepo.MarkRead(ctx, chatID, userID, msg.ID, msg.Seq)
+	if err != nil {
+		log.Error("failed to update read cursor", "error", err.Error())
+
+		return models.ReadReceipt{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return models.ReadReceipt{
+		ChatID:    chatID,
+		UserID:    userID,
+		MessageID: msg.ID,
+		Seq:       msg.Seq,
+		ReadAt:    time.Now(),
+	}, advanced, nil
+}
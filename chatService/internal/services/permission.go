@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+)
+
+// Permission is an action within a chat that a member's role may grant.
+type Permission int
+
+const (
+	// PermReadMessages allows reading history and subscribing to the chat.
+	PermReadMessages Permission = iota + 1
+	PermSendMessages
+	PermReact
+	PermViewMembers
+	// PermManageMembers allows adding members and removing lower-ranked ones.
+	PermManageMembers
+	PermEditAnyMessage
+	PermDeleteAnyMessage
+	// PermManageRoles allows changing the roles of other members.
+	PermManageRoles
+	// PermMentionEveryone makes @everyone notify all members.
+	PermMentionEveryone
+	// PermPinMessages allows pinning and unpinning messages.
+	PermPinMessages
+	// PermManageRetention allows changing how long messages are kept.
+	PermManageRetention
+	// PermExportChat allows exporting the whole history of the chat.
+	PermExportChat
+)
+
+var rolePermissions = map[models.Role][]Permission{
+	models.RoleOwner: {
+		PermReadMessages,
+		PermSendMessages,
+		PermReact,
+		PermViewMembers,
+		PermManageMembers,
+		PermEditAnyMessage,
+		PermDeleteAnyMessage,
+		PermManageRoles,
+		PermMentionEveryone,
+		PermPinMessages,
+		PermManageRetention,
+		PermExportChat,
+	},
+	models.RoleAdmin: {
+		PermReadMessages,
+		PermSendMessages,
+		PermReact,
+		PermViewMembers,
+		PermManageMembers,
+		PermEditAnyMessage,
+		PermDeleteAnyMessage,
+		PermMentionEveryone,
+		PermPinMessages,
+		PermExportChat,
+	},
+	models.RoleMember: {
+		PermReadMessages,
+		PermSendMessages,
+		PermReact,
+		PermViewMembers,
+	},
+	models.RoleReadOnly: {
+		PermReadMessages,
+		PermViewMembers,
+	},
+}
+
+// Can reports whether the role grants perm.
+func Can(role models.Role, perm Permission) bool {
+	return slices.Contains(rolePermissions[role], perm)
+}
+
+// Authorize returns the caller's membership in the chat if their role grants
+// perm, and ErrPermissionDenied otherwise. Non-members hold no permissions.
+func (c *ChatService) Authorize(ctx context.Context, chatID, userID int64, perm Permission) (models.Member, error) {
+	const op = "ChatService.Authorize"
+
+	member, err := c.memberRepo.Get(ctx, chatID, userID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrMemberNotFound) {
+			return models.Member{}, fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+		}
+
+		c.log.Error("failed to get membership",
+			slog.String("op", op),
+			slog.Int64("chat_id", chatID),
+			slog.Int64("user_id", userID),
+			"error", err.Error(),
+		)
+
+		return models.Member{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !Can(member.Role, perm) {
+		return models.Member{}, fmt.Errorf("%s: %w", op, ErrPermissionDenied)
+	}
+
+	return member, nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+)
+
+// maxPinnedMessages caps how many messages a single chat may have pinned.
+const maxPinnedMessages = 50
+
+type PinRepository interface {
+	Pin(ctx context.Context, chatID, messageID, userID int64, limit int) (time.Time, error)
+	Unpin(ctx context.Context, messageID int64) error
+	List(ctx context.Context, chatID int64) ([]models.Pin, error)
+}
+
+// PinMessage pins a message to the top of its chat. Only members allowed to
+// pin may do so, and tombstones cannot be pinned.
+func (c *ChatService) PinMessage(ctx context.Context, userID, messageID int64) (models.Pin, error) {
+	const op = "ChatService.PinMessage"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("message_id", messageID),
+	)
+
+	msg, err := c.messageRepo.Get(ctx, messageID)
+	if err != nil {
+		log.Warn("failed to get message", "error", err.Error())
+
+		return models.Pin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := c.Authorize(ctx, msg.ChatID, userID, PermPinMessages); err != nil {
+		return models.Pin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pinnedAt, err := c.pinRepo.Pin(ctx, msg.ChatID, msg.ID, userID, maxPinnedMessages)
+	if err != nil {
+		log.Warn("failed to pin message", "error", err.Error())
+
+		return models.Pin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msgs := []models.Message{msg}
+	if err := c.attachReactions(ctx, msgs); err != nil {
+		log.Error("failed to get reactions", "error", err.Error())
+
+		return models.Pin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.loadAttachments(ctx, msgs); err != nil {
+		log.Error("failed to get attachments", "error", err.Error())
+
+		return models.Pin{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("message pinned")
+
+	return models.Pin{
+		Message:  msgs[0],
+		PinnedBy: userID,
+		PinnedAt: pinnedAt,
+	}, nil
+}
+
+// UnpinMessage removes a pin and returns the message that was unpinned.
+func (c *ChatService) UnpinMessage(ctx context.Context, userID, messageID int64) (models.Message, error) {
+	const op = "ChatService.UnpinMessage"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("message_id", messageID),
+	)
+
+	msg, err := c.messageRepo.Get(ctx, messageID)
+	if err != nil {
+		log.Warn("failed to get message", "error", err.Error())
+
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := c.Authorize(ctx, msg.ChatID, userID, PermPinMessages); err != nil {
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.pinRepo.Unpin(ctx, messageID); err != nil {
+		log.Warn("failed to unpin message", "error", err.Error())
+
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("message unpinned")
+
+	return msg, nil
+}
+
+// ListPinnedMessages returns the pins of a chat, most recently pinned first.
+func (c *ChatService) ListPinnedMessages(ctx context.Context, userID, chatID int64) ([]models.Pin, error) {
+	const op = "ChatService.ListPinnedMessages"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("chat_id", chatID),
+	)
+
+	if _, err := c.Authorize(ctx, chatID, userID, PermReadMessages); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pins, err := c.pinRepo.List(ctx, chatID)
+	if err != nil {
+		log.Error("failed to get pins", "error", err.Error())
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msgs := make([]models.Message, len(pins))
+	for i, pin := range pins {
+		msgs[i] = pin.Message
+	}
+
+	if err := c.attachReactions(ctx, msgs); err != nil {
+		log.Error("failed to get reactions", "error", err.Error())
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.loadAttachments(ctx, msgs); err != nil {
+		log.Error("failed to get attachments", "error", err.Error())
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range pins {
+		pins[i].Message = msgs[i]
+	}
+
+	return pins, nil
+}
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+)
+
+var (
+	ErrNotConnected = errors.New("user has no open streams")
+	// ErrWatcherLagging ends a watcher that stopped keeping up with changes.
+	ErrWatcherLagging = errors.New("presence watcher fell behind")
+)
+
+// watcherBufferSize is how many changes a watcher may fall behind before it
+// is dropped.
+const watcherBufferSize = 16
+
+type PresenceRepository interface {
+	SetLastSeen(ctx context.Context, userID int64, at time.Time) error
+	LastSeen(ctx context.Context, userIDs []int64) (map[int64]time.Time, error)
+	SharingChat(ctx context.Context, userID int64, userIDs []int64) ([]int64, error)
+}
+
+// PresenceService tracks which users hold open streams. A user is online
+// while at least one stream is open, unless they marked themselves away, and
+// offline otherwise. Only the last-seen time outlives the process. Users can
+// only see the presence of themselves and of users they share a chat with.
+type PresenceService struct {
+	log  *slog.Logger
+	repo PresenceRepository
+
+	mu       sync.Mutex
+	streams  map[int64]int
+	away     map[int64]bool
+	watchers map[int64][]*PresenceWatcher
+}
+
+// PresenceWatcher is a feed of presence changes of some users on behalf of a
+// viewer. The service closes the channel when it drops the watcher and sets
+// err to the reason first.
+type PresenceWatcher struct {
+	viewerID int64
+	userIDs  []int64
+	ch       chan models.Presence
+	err      error
+	closed   bool
+}
+
+// Changes returns the channel presence changes are delivered on.
+func (w *PresenceWatcher) Changes() <-chan models.Presence {
+	return w.ch
+}
+
+// Err returns why the watcher was dropped. It is only meaningful once the
+// changes channel is closed, and nil after an Unwatch.
+func (w *PresenceWatcher) Err() error {
+	return w.err
+}
+
+func NewPresenceService(log *slog.Logger, repo PresenceRepository) *PresenceService {
+	return &PresenceService{
+		log:      log,
+		repo:     repo,
+		streams:  make(map[int64]int),
+		away:     make(map[int64]bool),
+		watchers: make(map[int64][]*PresenceWatcher),
+	}
+}
+
+// Connect records a newly opened stream of the user. Every call must be
+// paired with Disconnect.
+func (p *PresenceService) Connect(userID int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.streams[userID]++
+	if p.streams[userID] == 1 {
+		p.notify(models.Presence{UserID: userID, Status: models.PresenceOnline})
+	}
+}
+
+// Disconnect records that a stream of the user closed. When it was the last
+// one the user goes offline and the last-seen time is persisted.
+func (p *PresenceService) Disconnect(ctx context.Context, userID int64) error {
+	const op = "PresenceService.Disconnect"
+
+	log := p.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+	)
+
+	p.mu.Lock()
+	p.streams[userID]--
+	if p.streams[userID] > 0 {
+		p.mu.Unlock()
+		return nil
+	}
+
+	delete(p.streams, userID)
+	delete(p.away, userID)
+
+	now := time.Now()
+	p.notify(models.Presence{UserID: userID, Status: models.PresenceOffline, LastSeenAt: &now})
+	p.mu.Unlock()
+
+	// Writes racing with another disconnect of the user may land in either
+	// order; the repository keeps the later time.
+	if err := p.repo.SetLastSeen(ctx, userID, now); err != nil {
+		log.Error("failed to save last seen", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetAway switches a connected user between online and away. The away status
+// is dropped when the user's last stream closes.
+func (p *PresenceService) SetAway(userID int64, away bool) error {
+	const op = "PresenceService.SetAway"
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.streams[userID] == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNotConnected)
+	}
+
+	if p.away[userID] == away {
+		return nil
+	}
+
+	status := models.PresenceOnline
+	if away {
+		p.away[userID] = true
+		status = models.PresenceAway
+	} else {
+		delete(p.away, userID)
+	}
+
+	p.notify(models.Presence{UserID: userID, Status: status})
+
+	return nil
+}
+
+// GetPresence returns the presence of the given users in the same order.
+func (p *PresenceService) GetPresence(ctx context.Context, viewerID int64, userIDs []int64) ([]models.Presence, error) {
+	const op = "PresenceService.GetPresence"
+
+	log := p.log.With(slog.String("op", op))
+
+	if err := p.authorize(ctx, viewerID, userIDs); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	presences := make([]models.Presence, 0, len(userIDs))
+	var offline []int64
+
+	p.mu.Lock()
+	for _, userID := range userIDs {
+		presence := p.current(userID)
+		if presence.Status == models.PresenceOffline {
+			offline = append(offline, userID)
+		}
+		presences = append(presences, presence)
+	}
+	p.mu.Unlock()
+
+	if len(offline) == 0 {
+		return presences, nil
+	}
+
+	lastSeen, err := p.repo.LastSeen(ctx, offline)
+	if err != nil {
+		log.Error("failed to get last seen", "error", err.Error())
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range presences {
+		if at, ok := lastSeen[presences[i].UserID]; ok && presences[i].Status == models.PresenceOffline {
+			presences[i].LastSeenAt = &at
+		}
+	}
+
+	return presences, nil
+}
+
+// Watch subscribes to presence changes of the given users. A watcher that
+// falls behind is dropped with ErrWatcherLagging rather than skipping
+// changes, and one whose viewer stops sharing a chat with a watched user is
+// dropped with ErrPermissionDenied once Recheck runs. Every watcher must be
+// ended with Unwatch.
+func (p *PresenceService) Watch(ctx context.Context, viewerID int64, userIDs []int64) (*PresenceWatcher, error) {
+	const op = "PresenceService.Watch"
+
+	if err := p.authorize(ctx, viewerID, userIDs); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	w := &PresenceWatcher{
+		viewerID: viewerID,
+		userIDs:  userIDs,
+		ch:       make(chan models.Presence, watcherBufferSize),
+	}
+
+	p.mu.Lock()
+	for _, userID := range userIDs {
+		p.watchers[userID] = append(p.watchers[userID], w)
+	}
+	p.mu.Unlock()
+
+	return w, nil
+}
+
+// Unwatch ends a watcher and closes its channel, unless it was dropped
+// already.
+func (p *PresenceService) Unwatch(w *PresenceWatcher) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !w.closed {
+		p.dropWatcher(w, nil)
+	}
+}
+
+// Recheck drops the watchers that may no longer see whom they watch after
+// the user left a chat or was removed from one: those of the user and those
+// watching the user.
+func (p *PresenceService) Recheck(ctx context.Context, userID int64) {
+	const op = "PresenceService.Recheck"
+
+	p.mu.Lock()
+	var affected []*PresenceWatcher
+	seen := make(map[*PresenceWatcher]struct{})
+	for _, watchers := range p.watchers {
+		for _, w := range watchers {
+			if _, ok := seen[w]; ok {
+				continue
+			}
+			seen[w] = struct{}{}
+			if w.viewerID == userID || slices.Contains(w.userIDs, userID) {
+				affected = append(affected, w)
+			}
+		}
+	}
+	p.mu.Unlock()
+
+	for _, w := range affected {
+		err := p.authorize(ctx, w.viewerID, w.userIDs)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrPermissionDenied) {
+			p.log.Error("failed to recheck presence watcher",
+				slog.String("op", op),
+				slog.Int64("user_id", userID),
+				"error", err.Error(),
+			)
+			continue
+		}
+
+		p.mu.Lock()
+		if !w.closed {
+			p.dropWatcher(w, ErrPermissionDenied)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// authorize returns ErrPermissionDenied unless each of userIDs is the viewer
+// or shares a chat with them.
+func (p *PresenceService) authorize(ctx context.Context, viewerID int64, userIDs []int64) error {
+	const op = "PresenceService.authorize"
+
+	others := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID != viewerID {
+			others = append(others, userID)
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+
+	visible, err := p.repo.SharingChat(ctx, viewerID, others)
+	if err != nil {
+		p.log.Error("failed to check shared chats",
+			slog.String("op", op),
+			slog.Int64("user_id", viewerID),
+			"error", err.Error(),
+		)
+
+		return err
+	}
+
+	shared := make(map[int64]struct{}, len(visible))
+	for _, userID := range visible {
+		shared[userID] = struct{}{}
+	}
+	for _, userID := range others {
+		if _, ok := shared[userID]; !ok {
+			return ErrPermissionDenied
+		}
+	}
+
+	return nil
+}
+
+// current must be called with p.mu held.
+func (p *PresenceService) current(userID int64) models.Presence {
+	switch {
+	case p.streams[userID] == 0:
+		return models.Presence{UserID: userID, Status: models.PresenceOffline}
+	case p.away[userID]:
+		return models.Presence{UserID: userID, Status: models.PresenceAway}
+	default:
+		return models.Presence{UserID: userID, Status: models.PresenceOnline}
+	}
+}
+
+// notify must be called with p.mu held.
+func (p *PresenceService) notify(presence models.Presence) {
+	// Dropping edits the slice being ranged over, so range over a copy.
+	for _, w := range slices.Clone(p.watchers[presence.UserID]) {
+		select {
+		case w.ch <- presence:
+		default:
+			p.dropWatcher(w, ErrWatcherLagging)
+		}
+	}
+}
+
+// dropWatcher must be called with p.mu held.
+func (p *PresenceService) dropWatcher(w *PresenceWatcher, err error) {
+	for _, userID := range w.userIDs {
+		p.removeWatcher(userID, w)
+	}
+	w.err = err
+	w.closed = true
+	close(w.ch)
+}
+
+// removeWatcher must be called with p.mu held.
+func (p *PresenceService) removeWatcher(userID int64, w *PresenceWatcher) {
+	watchers := p.watchers[userID]
+	for i, other := range watchers {
+		if other == w {
+			watchers = append(watchers[:i], watchers[i+1:]...)
+			break
+		}
+	}
+
+	if len(watchers) == 0 {
+		delete(p.watchers, userID)
+		return
+	}
+	p.watchers[userID] = watchers
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+	"github.com/Gilf4/grpcChat/chat/internal/repository/postgres"
+)
+
+// AddReaction records the user's emoji reaction and returns the message with
+// its updated reaction counts.
+func (c *ChatService) AddReaction(ctx context.Context, userID, messageID int64, emoji string) (models.Message, error) {
+	const op = "ChatService.AddReaction"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("message_id", messageID),
+	)
+
+	msg, err := c.reactableMessage(ctx, userID, messageID)
+	if err != nil {
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.reactionRepo.Add(ctx, messageID, userID, emoji); err != nil {
+		log.Warn("failed to add reaction", "error", err.Error())
+
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msgs := []models.Message{msg}
+	if err := c.attachReactions(ctx, msgs); err != nil {
+		log.Error("failed to get reactions", "error", err.Error())
+
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msgs[0], nil
+}
+
+// RemoveReaction withdraws the user's emoji reaction and returns the message
+// with its updated reaction counts.
+func (c *ChatService) RemoveReaction(ctx context.Context, userID, messageID int64, emoji string) (models.Message, error) {
+	const op = "ChatService.RemoveReaction"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("message_id", messageID),
+	)
+
+	msg, err := c.reactableMessage(ctx, userID, messageID)
+	if err != nil {
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.reactionRepo.Remove(ctx, messageID, userID, emoji); err != nil {
+		log.Warn("failed to remove reaction", "error", err.Error())
+
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	msgs := []models.Message{msg}
+	if err := c.attachReactions(ctx, msgs); err != nil {
+		log.Error("failed to get reactions", "error", err.Error())
+
+		return models.Message{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return msgs[0], nil
+}
+
+func (c *ChatService) reactableMessage(ctx context.Context, userID, messageID int64) (models.Message, error) {
+	msg, err := c.messageRepo.Get(ctx, messageID)
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	if _, err := c.Authorize(ctx, msg.ChatID, userID, PermReact); err != nil {
+		return models.Message{}, err
+	}
+
+	// Add checks this again while it writes; a delete may commit meanwhile.
+	if msg.DeletedAt != nil {
+		return models.Message{}, postgres.ErrMessageDeleted
+	}
+
+	return msg, nil
+}
+
+// attachReactions fills in the reaction counts of msgs in place.
+func (c *ChatService) attachReactions(ctx context.Context, msgs []models.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(msgs))
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+	}
+
+	counts, err := c.reactionRepo.Counts(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range msgs {
+		msgs[i].Reactions = counts[msgs[i].ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+)
+
+var ErrInvalidRetention = errors.New("invalid retention")
+
+const (
+	maxRetentionDays    = 3650
+	minDisappearingTTL  = 5 * time.Second
+	maxDisappearingTTL  = 7 * 24 * time.Hour
+	retentionDayLength  = 24 * time.Hour
+	defaultJanitorBatch = 500
+)
+
+// ValidateRetention reports whether the retention is one the janitor can
+// enforce: days mode in whole days, disappearing within sensible bounds.
+func ValidateRetention(retention models.Retention) error {
+	switch retention.Mode {
+	case models.RetentionForever:
+		if retention.TTL != 0 {
+			return ErrInvalidRetention
+		}
+	case models.RetentionDays:
+		if retention.TTL <= 0 || retention.TTL%retentionDayLength != 0 ||
+			retention.TTL > maxRetentionDays*retentionDayLength {
+			return ErrInvalidRetention
+		}
+	case models.RetentionDisappearing:
+		if retention.TTL < minDisappearingTTL || retention.TTL > maxDisappearingTTL {
+			return ErrInvalidRetention
+		}
+	default:
+		return ErrInvalidRetention
+	}
+
+	return nil
+}
+
+// SetRetention changes how long the messages of a chat are kept. In group
+// chats this takes PermManageRetention; either participant of a direct chat
+// may change it. Messages already past the new limit are removed by the next
+// janitor run.
+func (c *ChatService) SetRetention(ctx context.Context, userID, chatID int64, retention models.Retention) error {
+	const op = "ChatService.SetRetention"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+		slog.Int64("chat_id", chatID),
+		slog.String("mode", string(retention.Mode)),
+		slog.Duration("ttl", retention.TTL),
+	)
+
+	if err := ValidateRetention(retention); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	chat, err := c.chatRepo.Get(ctx, chatID)
+	if err != nil {
+		log.Warn("failed to get chat", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	perm := PermManageRetention
+	if chat.Kind == models.ChatKindDirect {
+		perm = PermSendMessages
+	}
+
+	if _, err := c.Authorize(ctx, chatID, userID, perm); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.chatRepo.SetRetention(ctx, chatID, retention); err != nil {
+		log.Error("failed to set retention", "error", err.Error())
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("retention changed")
+
+	return nil
+}
+
+type ExpiringMessageRepository interface {
+	Expire(ctx context.Context, limit int) (models.Expired, error)
+}
+
+// ExpiryNotifier is told about messages the janitor removed so that open
+// streams can drop them, about the thread roots that lost replies and about
+// the pins that went with the messages.
+type ExpiryNotifier interface {
+	MessagesExpired(expired models.Expired)
+}
+
+// RetentionJanitor periodically removes messages that outlived the retention
+// of their chat, in batches so a large backlog never holds locks for long.
+type RetentionJanitor struct {
+	log       *slog.Logger
+	repo      ExpiringMessageRepository
+	blobs     BlobStore
+	notifier  ExpiryNotifier
+	interval  time.Duration
+	batchSize int
+}
+
+func NewRetentionJanitor(
+	log *slog.Logger,
+	repo ExpiringMessageRepository,
+	blobs BlobStore,
+	notifier ExpiryNotifier,
+	interval time.Duration,
+	batchSize int,
+) *RetentionJanitor {
+	if batchSize <= 0 {
+		batchSize = defaultJanitorBatch
+	}
+
+	return &RetentionJanitor{
+		log:       log,
+		repo:      repo,
+		blobs:     blobs,
+		notifier:  notifier,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run sweeps every interval until ctx is cancelled.
+func (j *RetentionJanitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.Sweep(ctx)
+		}
+	}
+}
+
+// Sweep removes expired messages batch by batch until none are left.
+func (j *RetentionJanitor) Sweep(ctx context.Context) {
+	const op = "RetentionJanitor.Sweep"
+
+	log := j.log.With(slog.String("op", op))
+
+	for ctx.Err() == nil {
+		expired, err := j.repo.Expire(ctx, j.batchSize)
+		if err != nil {
+			log.Error("failed to expire messages", "error", err.Error())
+			return
+		}
+
+		for _, key := range expired.BlobKeys {
+			if err := j.blobs.Delete(context.WithoutCancel(ctx), key); err != nil {
+				log.Error("failed to delete blob", "key", key, "error", err.Error())
+			}
+		}
+
+		if len(expired.Messages) > 0 {
+			j.notifier.MessagesExpired(expired)
+			log.Info("messages expired", slog.Int("count", len(expired.Messages)))
+		}
+
+		if len(expired.Messages) < j.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+)
+
+func TestValidateRetention(t *testing.T) {
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		retention models.Retention
+		wantErr   bool
+	}{
+		{name: "forever", retention: models.Retention{Mode: models.RetentionForever}},
+		{name: "forever with ttl", retention: models.Retention{Mode: models.RetentionForever, TTL: day}, wantErr: true},
+		{name: "one day", retention: models.Retention{Mode: models.RetentionDays, TTL: day}},
+		{name: "ten years", retention: models.Retention{Mode: models.RetentionDays, TTL: maxRetentionDays * day}},
+		{name: "over ten years", retention: models.Retention{Mode: models.RetentionDays, TTL: (maxRetentionDays + 1) * day}, wantErr: true},
+		{name: "partial day", retention: models.Retention{Mode: models.RetentionDays, TTL: day + time.Hour}, wantErr: true},
+		{name: "zero days", retention: models.Retention{Mode: models.RetentionDays}, wantErr: true},
+		{name: "negative days", retention: models.Retention{Mode: models.RetentionDays, TTL: -day}, wantErr: true},
+		{name: "shortest disappearing", retention: models.Retention{Mode: models.RetentionDisappearing, TTL: minDisappearingTTL}},
+		{name: "longest disappearing", retention: models.Retention{Mode: models.RetentionDisappearing, TTL: maxDisappearingTTL}},
+		{name: "odd disappearing", retention: models.Retention{Mode: models.RetentionDisappearing, TTL: 90 * time.Second}},
+		{name: "disappearing too fast", retention: models.Retention{Mode: models.RetentionDisappearing, TTL: time.Second}, wantErr: true},
+		{name: "disappearing too slow", retention: models.Retention{Mode: models.RetentionDisappearing, TTL: maxDisappearingTTL + time.Second}, wantErr: true},
+		{name: "no mode", retention: models.Retention{TTL: day}, wantErr: true},
+		{name: "unknown mode", retention: models.Retention{Mode: "weekly", TTL: 7 * day}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRetention(tt.retention)
+			if tt.wantErr && !errors.Is(err, ErrInvalidRetention) {
+				t.Errorf("ValidateRetention(%+v) = %v, want ErrInvalidRetention", tt.retention, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("ValidateRetention(%+v) = %v, want nil", tt.retention, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Gilf4/grpcChat/chat/internal/domain/models"
+)
+
+// SearchMessages runs a full-text search over the chats the user is a member
+// of. Results are newest first; the next cursor is zero on the last page.
+func (c *ChatService) SearchMessages(
+	ctx context.Context,
+	userID int64,
+	filter models.SearchFilter,
+	cursor int64,
+	limit int,
+) ([]models.SearchResult, int64, error) {
+	const op = "ChatService.SearchMessages"
+
+	log := c.log.With(
+		slog.String("op", op),
+		slog.Int64("user_id", userID),
+	)
+
+	limit = pageLimit(limit)
+
+	results, err := c.messageRepo.Search(ctx, userID, filter, cursor, limit+1)
+	if err != nil {
+		log.Error("failed to search messages", "error", err.Error())
+
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var next int64
+	if len(results) > limit {
+		results = results[:limit]
+		next = results[limit-1].Message.ID
+	}
+
+	return results, next, nil
+}
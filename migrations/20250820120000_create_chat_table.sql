@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE chats (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE chats;
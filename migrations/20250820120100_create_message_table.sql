@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE messages (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    sender_id BIGINT NOT NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_messages_chat_id ON messages(chat_id);
+
+-- +goose Down
+DROP TABLE messages;
@@ -0,0 +1,7 @@
+-- +goose Up
+DROP INDEX idx_messages_chat_id;
+CREATE INDEX idx_messages_chat_id_id ON messages(chat_id, id);
+
+-- +goose Down
+DROP INDEX idx_messages_chat_id_id;
+CREATE INDEX idx_messages_chat_id ON messages(chat_id);
@@ -0,0 +1,37 @@
+-- +goose Up
+ALTER TABLE chats
+    ADD COLUMN last_seq BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE messages
+    ADD COLUMN seq BIGINT;
+
+UPDATE messages m
+SET seq = numbered.seq
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY chat_id ORDER BY id) AS seq
+    FROM messages
+) numbered
+WHERE m.id = numbered.id;
+
+UPDATE chats c
+SET last_seq = counted.last_seq
+FROM (
+    SELECT chat_id, max(seq) AS last_seq
+    FROM messages
+    GROUP BY chat_id
+) counted
+WHERE c.id = counted.chat_id;
+
+ALTER TABLE messages
+    ALTER COLUMN seq SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_messages_chat_id_seq ON messages(chat_id, seq);
+
+-- +goose Down
+DROP INDEX idx_messages_chat_id_seq;
+
+ALTER TABLE messages
+    DROP COLUMN seq;
+
+ALTER TABLE chats
+    DROP COLUMN last_seq;
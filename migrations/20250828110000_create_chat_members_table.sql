@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE chat_members (
+    chat_id BIGINT NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    joined_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (chat_id, user_id)
+);
+
+CREATE INDEX idx_chat_members_user_id ON chat_members(user_id);
+
+INSERT INTO chat_members (chat_id, user_id)
+SELECT DISTINCT chat_id, sender_id
+FROM messages;
+
+-- +goose Down
+DROP TABLE chat_members;
@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE chat_members
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'member'
+        CHECK (role IN ('owner', 'admin', 'member', 'read_only'));
+
+UPDATE chat_members
+SET role = 'owner'
+WHERE (chat_id, user_id) IN (
+    SELECT DISTINCT ON (chat_id) chat_id, user_id
+    FROM chat_members
+    ORDER BY chat_id, joined_at, user_id
+);
+
+-- +goose Down
+ALTER TABLE chat_members
+    DROP COLUMN role;
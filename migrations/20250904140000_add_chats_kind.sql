@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE chats
+    ADD COLUMN kind TEXT NOT NULL DEFAULT 'group'
+        CHECK (kind IN ('group', 'direct')),
+    ADD COLUMN direct_user_low BIGINT,
+    ADD COLUMN direct_user_high BIGINT,
+    ADD CONSTRAINT chats_direct_users_check
+        CHECK ((kind = 'direct') = (direct_user_low IS NOT NULL AND direct_user_high IS NOT NULL));
+
+CREATE UNIQUE INDEX idx_chats_direct_users ON chats(direct_user_low, direct_user_high)
+    WHERE kind = 'direct';
+
+-- +goose Down
+DROP INDEX idx_chats_direct_users;
+
+ALTER TABLE chats
+    DROP CONSTRAINT chats_direct_users_check,
+    DROP COLUMN direct_user_high,
+    DROP COLUMN direct_user_low,
+    DROP COLUMN kind;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE messages
+    ADD COLUMN edited_at TIMESTAMP,
+    ADD COLUMN deleted_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE messages
+    DROP COLUMN deleted_at,
+    DROP COLUMN edited_at;
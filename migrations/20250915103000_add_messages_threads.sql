@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE messages
+    ADD COLUMN reply_to_id BIGINT REFERENCES messages(id),
+    ADD COLUMN reply_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN last_reply_at TIMESTAMP;
+
+CREATE INDEX idx_messages_reply_to_id_id ON messages(reply_to_id, id)
+    WHERE reply_to_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_messages_reply_to_id_id;
+
+ALTER TABLE messages
+    DROP COLUMN last_reply_at,
+    DROP COLUMN reply_count,
+    DROP COLUMN reply_to_id;
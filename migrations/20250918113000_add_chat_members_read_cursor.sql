@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE chat_members
+    ADD COLUMN last_read_message_id BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN last_read_seq BIGINT NOT NULL DEFAULT 0;
+
+UPDATE chat_members m
+SET last_read_seq = c.last_seq,
+    last_read_message_id = COALESCE(
+        (SELECT max(id) FROM messages WHERE chat_id = m.chat_id), 0)
+FROM chats c
+WHERE c.id = m.chat_id;
+
+-- +goose Down
+ALTER TABLE chat_members
+    DROP COLUMN last_read_seq,
+    DROP COLUMN last_read_message_id;
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE messages
+    ADD COLUMN search_vector TSVECTOR
+        GENERATED ALWAYS AS (to_tsvector('simple', text)) STORED;
+
+CREATE INDEX idx_messages_search_vector ON messages USING GIN (search_vector);
+
+-- +goose Down
+DROP INDEX idx_messages_search_vector;
+
+ALTER TABLE messages DROP COLUMN search_vector;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE attachments (
+    id BIGSERIAL PRIMARY KEY,
+    chat_id BIGINT NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    uploader_id BIGINT NOT NULL,
+    -- NULL until the attachment is sent with a message.
+    message_id BIGINT REFERENCES messages(id) ON DELETE CASCADE,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_attachments_message_id ON attachments(message_id)
+    WHERE message_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE attachments;
@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE attachments
+    ADD COLUMN width INT NOT NULL DEFAULT 0,
+    ADD COLUMN height INT NOT NULL DEFAULT 0;
+
+CREATE TABLE attachment_thumbnails (
+    id BIGSERIAL PRIMARY KEY,
+    attachment_id BIGINT NOT NULL REFERENCES attachments(id) ON DELETE CASCADE,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE
+);
+
+CREATE INDEX idx_attachment_thumbnails_attachment_id ON attachment_thumbnails(attachment_id);
+
+-- +goose Down
+DROP TABLE attachment_thumbnails;
+
+ALTER TABLE attachments
+    DROP COLUMN height,
+    DROP COLUMN width;
@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE messages ADD COLUMN mentioned_user_ids BIGINT[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE mentions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    chat_id BIGINT NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    message_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    read_at TIMESTAMP,
+    UNIQUE (message_id, user_id)
+);
+
+CREATE INDEX idx_mentions_user_id_id ON mentions(user_id, id);
+
+-- +goose Down
+DROP TABLE mentions;
+
+ALTER TABLE messages DROP COLUMN mentioned_user_ids;
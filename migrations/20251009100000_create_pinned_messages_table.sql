@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE pinned_messages (
+    message_id BIGINT PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    chat_id BIGINT NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    pinned_by BIGINT NOT NULL,
+    pinned_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_pinned_messages_chat_id ON pinned_messages(chat_id, pinned_at);
+
+-- +goose Down
+DROP TABLE pinned_messages;
@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE chats
+    ADD COLUMN retention_mode TEXT NOT NULL DEFAULT 'forever'
+        CHECK (retention_mode IN ('forever', 'days', 'disappearing')),
+    ADD COLUMN retention_ttl BIGINT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chats_retention_ttl_check
+        CHECK ((retention_mode = 'forever') = (retention_ttl = 0) AND retention_ttl >= 0);
+
+CREATE INDEX idx_messages_chat_id_created_at ON messages(chat_id, created_at);
+
+-- +goose Down
+DROP INDEX idx_messages_chat_id_created_at;
+
+ALTER TABLE chats
+    DROP CONSTRAINT chats_retention_ttl_check,
+    DROP COLUMN retention_ttl,
+    DROP COLUMN retention_mode;
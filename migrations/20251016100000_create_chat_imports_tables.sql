@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE chat_imports (
+    source_chat_id BIGINT NOT NULL,
+    source_created_at TIMESTAMP NOT NULL,
+    chat_id BIGINT NOT NULL UNIQUE REFERENCES chats(id) ON DELETE CASCADE,
+    imported_by BIGINT NOT NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT now(),
+    -- NULL while the import is incomplete; the chat has no members until then.
+    completed_at TIMESTAMP,
+    PRIMARY KEY (source_chat_id, source_created_at)
+);
+
+CREATE TABLE imported_messages (
+    chat_id BIGINT NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    source_message_id BIGINT NOT NULL,
+    message_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    PRIMARY KEY (chat_id, source_message_id)
+);
+
+CREATE INDEX idx_imported_messages_message_id ON imported_messages(message_id);
+
+-- +goose Down
+DROP TABLE imported_messages;
+DROP TABLE chat_imports;
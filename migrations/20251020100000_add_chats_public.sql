@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE chats
+    ADD COLUMN public BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE chats
+    DROP COLUMN public;
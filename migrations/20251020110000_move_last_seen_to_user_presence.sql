@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE user_presence (
+    user_id BIGINT PRIMARY KEY,
+    last_seen_at TIMESTAMP NOT NULL
+);
+
+INSERT INTO user_presence (user_id, last_seen_at)
+SELECT id, last_seen_at
+FROM users
+WHERE last_seen_at IS NOT NULL;
+
+ALTER TABLE users DROP COLUMN last_seen_at;
+
+-- +goose Down
+ALTER TABLE users ADD COLUMN last_seen_at TIMESTAMP;
+
+UPDATE users u
+SET last_seen_at = p.last_seen_at
+FROM user_presence p
+WHERE u.id = p.user_id;
+
+DROP TABLE user_presence;
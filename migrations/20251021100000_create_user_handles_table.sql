@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE user_handles (
+    user_id BIGINT PRIMARY KEY,
+    handle TEXT NOT NULL UNIQUE
+);
+
+-- Names that are already unique and valid handles keep working in mentions.
+INSERT INTO user_handles (user_id, handle)
+SELECT min(id), lower(name)
+FROM users
+WHERE lower(name) ~ '^[[:alnum:]_]([[:alnum:]_.-]*[[:alnum:]_-])?$'
+    AND char_length(name) <= 32
+    AND lower(name) <> 'everyone'
+GROUP BY lower(name)
+HAVING count(*) = 1;
+
+-- +goose Down
+DROP TABLE user_handles;
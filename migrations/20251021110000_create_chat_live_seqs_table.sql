@@ -0,0 +1,19 @@
+-- +goose Up
+-- min_live_seq is the lowest seq of a chat that retention may not have removed
+-- yet; unread counts skip everything below it. Chats without a row have lost
+-- nothing to retention. It lives apart from chats so that the janitor never
+-- waits for the chat row, which sending a message locks.
+CREATE TABLE chat_live_seqs (
+    chat_id BIGINT PRIMARY KEY REFERENCES chats(id) ON DELETE CASCADE,
+    min_live_seq BIGINT NOT NULL
+);
+
+INSERT INTO chat_live_seqs (chat_id, min_live_seq)
+SELECT c.id, COALESCE(
+    (SELECT min(m.seq) FROM messages m WHERE m.chat_id = c.id AND m.deleted_at IS NULL),
+    c.last_seq + 1
+)
+FROM chats c;
+
+-- +goose Down
+DROP TABLE chat_live_seqs;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Deleted replies no longer count towards their thread.
+UPDATE messages r
+SET reply_count = t.n, last_reply_at = t.last_reply_at
+FROM (
+    SELECT root.id, count(m.id) AS n, max(m.created_at) AS last_reply_at
+    FROM messages root
+    LEFT JOIN messages m ON m.reply_to_id = root.id AND m.deleted_at IS NULL
+    WHERE root.reply_count > 0
+    GROUP BY root.id
+) t
+WHERE r.id = t.id;
+
+-- +goose Down
+UPDATE messages r
+SET reply_count = t.n, last_reply_at = t.last_reply_at
+FROM (
+    SELECT reply_to_id AS id, count(*) AS n, max(created_at) AS last_reply_at
+    FROM messages
+    WHERE reply_to_id IS NOT NULL
+    GROUP BY reply_to_id
+) t
+WHERE r.id = t.id;
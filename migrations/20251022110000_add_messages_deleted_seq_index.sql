@@ -0,0 +1,6 @@
+-- +goose Up
+-- Unread counts subtract the tombstones past a member's read cursor.
+CREATE INDEX idx_messages_chat_id_seq_deleted ON messages(chat_id, seq) WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_messages_chat_id_seq_deleted;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Tombstones no longer reveal whom the deleted text mentioned.
+DELETE FROM mentions
+WHERE message_id IN (SELECT id FROM messages WHERE deleted_at IS NOT NULL);
+
+UPDATE messages
+SET mentioned_user_ids = '{}'
+WHERE deleted_at IS NOT NULL AND mentioned_user_ids <> '{}';
+
+-- +goose Down
+-- The cleared mentions cannot be restored.
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,31 +23,4422 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ChatKind int32
+
+const (
+	ChatKind_CHAT_KIND_UNSPECIFIED ChatKind = 0
+	ChatKind_CHAT_KIND_GROUP       ChatKind = 1
+	ChatKind_CHAT_KIND_DIRECT      ChatKind = 2
+)
+
+// Enum value maps for ChatKind.
+var (
+	ChatKind_name = map[int32]string{
+		0: "CHAT_KIND_UNSPECIFIED",
+		1: "CHAT_KIND_GROUP",
+		2: "CHAT_KIND_DIRECT",
+	}
+	ChatKind_value = map[string]int32{
+		"CHAT_KIND_UNSPECIFIED": 0,
+		"CHAT_KIND_GROUP":       1,
+		"CHAT_KIND_DIRECT":      2,
+	}
+)
+
+func (x ChatKind) Enum() *ChatKind {
+	p := new(ChatKind)
+	*p = x
+	return p
+}
+
+func (x ChatKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_chat_v1_chat_proto_enumTypes[0].Descriptor()
+}
+
+func (ChatKind) Type() protoreflect.EnumType {
+	return &file_chat_v1_chat_proto_enumTypes[0]
+}
+
+func (x ChatKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatKind.Descriptor instead.
+func (ChatKind) EnumDescriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{0}
+}
+
+type RetentionMode int32
+
+const (
+	RetentionMode_RETENTION_MODE_UNSPECIFIED RetentionMode = 0
+	RetentionMode_RETENTION_MODE_FOREVER     RetentionMode = 1
+	// Messages are removed a number of days after they were sent.
+	RetentionMode_RETENTION_MODE_DAYS RetentionMode = 2
+	// Messages are removed shortly after they were sent.
+	RetentionMode_RETENTION_MODE_DISAPPEARING RetentionMode = 3
+)
+
+// Enum value maps for RetentionMode.
+var (
+	RetentionMode_name = map[int32]string{
+		0: "RETENTION_MODE_UNSPECIFIED",
+		1: "RETENTION_MODE_FOREVER",
+		2: "RETENTION_MODE_DAYS",
+		3: "RETENTION_MODE_DISAPPEARING",
+	}
+	RetentionMode_value = map[string]int32{
+		"RETENTION_MODE_UNSPECIFIED":  0,
+		"RETENTION_MODE_FOREVER":      1,
+		"RETENTION_MODE_DAYS":         2,
+		"RETENTION_MODE_DISAPPEARING": 3,
+	}
+)
+
+func (x RetentionMode) Enum() *RetentionMode {
+	p := new(RetentionMode)
+	*p = x
+	return p
+}
+
+func (x RetentionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RetentionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_chat_v1_chat_proto_enumTypes[1].Descriptor()
+}
+
+func (RetentionMode) Type() protoreflect.EnumType {
+	return &file_chat_v1_chat_proto_enumTypes[1]
+}
+
+func (x RetentionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RetentionMode.Descriptor instead.
+func (RetentionMode) EnumDescriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{1}
+}
+
+type Direction int32
+
+const (
+	Direction_DIRECTION_UNSPECIFIED Direction = 0
+	Direction_DIRECTION_OLDER       Direction = 1
+	Direction_DIRECTION_NEWER       Direction = 2
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "DIRECTION_UNSPECIFIED",
+		1: "DIRECTION_OLDER",
+		2: "DIRECTION_NEWER",
+	}
+	Direction_value = map[string]int32{
+		"DIRECTION_UNSPECIFIED": 0,
+		"DIRECTION_OLDER":       1,
+		"DIRECTION_NEWER":       2,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_chat_v1_chat_proto_enumTypes[2].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_chat_v1_chat_proto_enumTypes[2]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{2}
+}
+
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_OWNER       Role = 1
+	Role_ROLE_ADMIN       Role = 2
+	Role_ROLE_MEMBER      Role = 3
+	Role_ROLE_READ_ONLY   Role = 4
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_OWNER",
+		2: "ROLE_ADMIN",
+		3: "ROLE_MEMBER",
+		4: "ROLE_READ_ONLY",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_OWNER":       1,
+		"ROLE_ADMIN":       2,
+		"ROLE_MEMBER":      3,
+		"ROLE_READ_ONLY":   4,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_chat_v1_chat_proto_enumTypes[3].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_chat_v1_chat_proto_enumTypes[3]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{3}
+}
+
+type PresenceStatus int32
+
+const (
+	PresenceStatus_PRESENCE_STATUS_UNSPECIFIED PresenceStatus = 0
+	PresenceStatus_PRESENCE_STATUS_ONLINE      PresenceStatus = 1
+	PresenceStatus_PRESENCE_STATUS_AWAY        PresenceStatus = 2
+	PresenceStatus_PRESENCE_STATUS_OFFLINE     PresenceStatus = 3
+)
+
+// Enum value maps for PresenceStatus.
+var (
+	PresenceStatus_name = map[int32]string{
+		0: "PRESENCE_STATUS_UNSPECIFIED",
+		1: "PRESENCE_STATUS_ONLINE",
+		2: "PRESENCE_STATUS_AWAY",
+		3: "PRESENCE_STATUS_OFFLINE",
+	}
+	PresenceStatus_value = map[string]int32{
+		"PRESENCE_STATUS_UNSPECIFIED": 0,
+		"PRESENCE_STATUS_ONLINE":      1,
+		"PRESENCE_STATUS_AWAY":        2,
+		"PRESENCE_STATUS_OFFLINE":     3,
+	}
+)
+
+func (x PresenceStatus) Enum() *PresenceStatus {
+	p := new(PresenceStatus)
+	*p = x
+	return p
+}
+
+func (x PresenceStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PresenceStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_chat_v1_chat_proto_enumTypes[4].Descriptor()
+}
+
+func (PresenceStatus) Type() protoreflect.EnumType {
+	return &file_chat_v1_chat_proto_enumTypes[4]
+}
+
+func (x PresenceStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PresenceStatus.Descriptor instead.
+func (PresenceStatus) EnumDescriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{4}
+}
+
 type Message struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ChatId        int64                  `protobuf:"varint,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	SenderId      int64                  `protobuf:"varint,3,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChatId    int64                  `protobuf:"varint,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	SenderId  int64                  `protobuf:"varint,3,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	Text      string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Position of the message within its chat, starting at 1 with no gaps.
+	Seq int64 `protobuf:"varint,6,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Unset unless the message has been edited.
+	EditedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	// Deleted messages are kept as tombstones with an empty text.
+	Deleted bool `protobuf:"varint,8,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// Aggregated reactions. Only populated in history results.
+	Reactions []*ReactionCount `protobuf:"bytes,9,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	// Root of the thread this message replies to, zero for top-level messages.
+	ReplyToMessageId int64 `protobuf:"varint,10,opt,name=reply_to_message_id,json=replyToMessageId,proto3" json:"reply_to_message_id,omitempty"`
+	// Thread statistics, set on thread roots. Deleted replies are not
+	// counted, and last_reply_at is the time of the newest remaining reply.
+	ReplyCount  int64                  `protobuf:"varint,11,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`
+	LastReplyAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=last_reply_at,json=lastReplyAt,proto3" json:"last_reply_at,omitempty"`
+	Attachments []*Attachment          `protobuf:"bytes,13,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// Members mentioned with @handle or @everyone in the current text. Empty
+	// on deleted messages.
+	MentionedUserIds []int64 `protobuf:"varint,14,rep,packed,name=mentioned_user_ids,json=mentionedUserIds,proto3" json:"mentioned_user_ids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	mi := &file_chat_v1_chat_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Message) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Message) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *Message) GetSenderId() int64 {
+	if x != nil {
+		return x.SenderId
+	}
+	return 0
+}
+
+func (x *Message) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Message) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Message) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Message) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+func (x *Message) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Message) GetReactions() []*ReactionCount {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *Message) GetReplyToMessageId() int64 {
+	if x != nil {
+		return x.ReplyToMessageId
+	}
+	return 0
+}
+
+func (x *Message) GetReplyCount() int64 {
+	if x != nil {
+		return x.ReplyCount
+	}
+	return 0
+}
+
+func (x *Message) GetLastReplyAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastReplyAt
+	}
+	return nil
+}
+
+func (x *Message) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *Message) GetMentionedUserIds() []int64 {
+	if x != nil {
+		return x.MentionedUserIds
+	}
+	return nil
+}
+
+type Attachment struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Detected from the content on upload.
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size        int64  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// Dimensions of image attachments, zero otherwise.
+	Width  int32 `protobuf:"varint,5,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	// Previews of PNG, JPEG and GIF images, smallest first.
+	Thumbnails    []*Thumbnail `protobuf:"bytes,7,rep,name=thumbnails,proto3" json:"thumbnails,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_chat_v1_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Attachment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Attachment) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Attachment) GetThumbnails() []*Thumbnail {
+	if x != nil {
+		return x.Thumbnails
+	}
+	return nil
+}
+
+// Thumbnail is a downscaled preview of an image attachment, fetched with
+// DownloadAttachment.
+type Thumbnail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Thumbnail) Reset() {
+	*x = Thumbnail{}
+	mi := &file_chat_v1_chat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Thumbnail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Thumbnail) ProtoMessage() {}
+
+func (x *Thumbnail) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Thumbnail.ProtoReflect.Descriptor instead.
+func (*Thumbnail) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Thumbnail) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Thumbnail) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Thumbnail) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Thumbnail) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Thumbnail) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ReactionCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emoji         string                 `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionCount) Reset() {
+	*x = ReactionCount{}
+	mi := &file_chat_v1_chat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionCount) ProtoMessage() {}
+
+func (x *ReactionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionCount.ProtoReflect.Descriptor instead.
+func (*ReactionCount) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReactionCount) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactionCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// ChatEvent is a single change in a chat delivered over ConnectChat, Session
+// and StreamEvents.
+type ChatEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*ChatEvent_MessageCreated
+	//	*ChatEvent_MessageEdited
+	//	*ChatEvent_MessageDeleted
+	//	*ChatEvent_ReactionsChanged
+	//	*ChatEvent_ReadReceipt
+	//	*ChatEvent_Typing
+	//	*ChatEvent_MemberJoined
+	//	*ChatEvent_MemberLeft
+	//	*ChatEvent_Mention
+	//	*ChatEvent_MessagePinned
+	//	*ChatEvent_MessageUnpinned
+	//	*ChatEvent_RetentionChanged
+	//	*ChatEvent_ThreadUpdated
+	Event         isChatEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatEvent) Reset() {
+	*x = ChatEvent{}
+	mi := &file_chat_v1_chat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatEvent) ProtoMessage() {}
+
+func (x *ChatEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatEvent.ProtoReflect.Descriptor instead.
+func (*ChatEvent) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChatEvent) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *ChatEvent) GetEvent() isChatEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetMessageCreated() *Message {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_MessageCreated); ok {
+			return x.MessageCreated
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetMessageEdited() *Message {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_MessageEdited); ok {
+			return x.MessageEdited
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetMessageDeleted() *MessageDeleted {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_MessageDeleted); ok {
+			return x.MessageDeleted
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetReactionsChanged() *ReactionsChanged {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_ReactionsChanged); ok {
+			return x.ReactionsChanged
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetReadReceipt() *ReadReceipt {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_ReadReceipt); ok {
+			return x.ReadReceipt
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetTyping() *Typing {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_Typing); ok {
+			return x.Typing
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetMemberJoined() *MemberJoined {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_MemberJoined); ok {
+			return x.MemberJoined
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetMemberLeft() *MemberLeft {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_MemberLeft); ok {
+			return x.MemberLeft
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetMention() *Mention {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_Mention); ok {
+			return x.Mention
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetMessagePinned() *Pin {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_MessagePinned); ok {
+			return x.MessagePinned
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetMessageUnpinned() *MessageUnpinned {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_MessageUnpinned); ok {
+			return x.MessageUnpinned
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetRetentionChanged() *RetentionChanged {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_RetentionChanged); ok {
+			return x.RetentionChanged
+		}
+	}
+	return nil
+}
+
+func (x *ChatEvent) GetThreadUpdated() *ThreadUpdated {
+	if x != nil {
+		if x, ok := x.Event.(*ChatEvent_ThreadUpdated); ok {
+			return x.ThreadUpdated
+		}
+	}
+	return nil
+}
+
+type isChatEvent_Event interface {
+	isChatEvent_Event()
+}
+
+type ChatEvent_MessageCreated struct {
+	MessageCreated *Message `protobuf:"bytes,2,opt,name=message_created,json=messageCreated,proto3,oneof"`
+}
+
+type ChatEvent_MessageEdited struct {
+	MessageEdited *Message `protobuf:"bytes,3,opt,name=message_edited,json=messageEdited,proto3,oneof"`
+}
+
+type ChatEvent_MessageDeleted struct {
+	MessageDeleted *MessageDeleted `protobuf:"bytes,4,opt,name=message_deleted,json=messageDeleted,proto3,oneof"`
+}
+
+type ChatEvent_ReactionsChanged struct {
+	ReactionsChanged *ReactionsChanged `protobuf:"bytes,5,opt,name=reactions_changed,json=reactionsChanged,proto3,oneof"`
+}
+
+type ChatEvent_ReadReceipt struct {
+	ReadReceipt *ReadReceipt `protobuf:"bytes,6,opt,name=read_receipt,json=readReceipt,proto3,oneof"`
+}
+
+type ChatEvent_Typing struct {
+	Typing *Typing `protobuf:"bytes,7,opt,name=typing,proto3,oneof"`
+}
+
+type ChatEvent_MemberJoined struct {
+	MemberJoined *MemberJoined `protobuf:"bytes,8,opt,name=member_joined,json=memberJoined,proto3,oneof"`
+}
+
+type ChatEvent_MemberLeft struct {
+	MemberLeft *MemberLeft `protobuf:"bytes,9,opt,name=member_left,json=memberLeft,proto3,oneof"`
+}
+
+type ChatEvent_Mention struct {
+	Mention *Mention `protobuf:"bytes,10,opt,name=mention,proto3,oneof"`
+}
+
+type ChatEvent_MessagePinned struct {
+	MessagePinned *Pin `protobuf:"bytes,11,opt,name=message_pinned,json=messagePinned,proto3,oneof"`
+}
+
+type ChatEvent_MessageUnpinned struct {
+	MessageUnpinned *MessageUnpinned `protobuf:"bytes,12,opt,name=message_unpinned,json=messageUnpinned,proto3,oneof"`
+}
+
+type ChatEvent_RetentionChanged struct {
+	RetentionChanged *RetentionChanged `protobuf:"bytes,13,opt,name=retention_changed,json=retentionChanged,proto3,oneof"`
+}
+
+type ChatEvent_ThreadUpdated struct {
+	ThreadUpdated *ThreadUpdated `protobuf:"bytes,14,opt,name=thread_updated,json=threadUpdated,proto3,oneof"`
+}
+
+func (*ChatEvent_MessageCreated) isChatEvent_Event() {}
+
+func (*ChatEvent_MessageEdited) isChatEvent_Event() {}
+
+func (*ChatEvent_MessageDeleted) isChatEvent_Event() {}
+
+func (*ChatEvent_ReactionsChanged) isChatEvent_Event() {}
+
+func (*ChatEvent_ReadReceipt) isChatEvent_Event() {}
+
+func (*ChatEvent_Typing) isChatEvent_Event() {}
+
+func (*ChatEvent_MemberJoined) isChatEvent_Event() {}
+
+func (*ChatEvent_MemberLeft) isChatEvent_Event() {}
+
+func (*ChatEvent_Mention) isChatEvent_Event() {}
+
+func (*ChatEvent_MessagePinned) isChatEvent_Event() {}
+
+func (*ChatEvent_MessageUnpinned) isChatEvent_Event() {}
+
+func (*ChatEvent_RetentionChanged) isChatEvent_Event() {}
+
+func (*ChatEvent_ThreadUpdated) isChatEvent_Event() {}
+
+// ThreadUpdated carries the new reply counters of a thread root after a
+// reply was sent, deleted or expired. last_reply_at is unset once no replies
+// are left.
+type ThreadUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RootMessageId int64                  `protobuf:"varint,1,opt,name=root_message_id,json=rootMessageId,proto3" json:"root_message_id,omitempty"`
+	ReplyCount    int64                  `protobuf:"varint,2,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`
+	LastReplyAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_reply_at,json=lastReplyAt,proto3" json:"last_reply_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ThreadUpdated) Reset() {
+	*x = ThreadUpdated{}
+	mi := &file_chat_v1_chat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThreadUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThreadUpdated) ProtoMessage() {}
+
+func (x *ThreadUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ThreadUpdated.ProtoReflect.Descriptor instead.
+func (*ThreadUpdated) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ThreadUpdated) GetRootMessageId() int64 {
+	if x != nil {
+		return x.RootMessageId
+	}
+	return 0
+}
+
+func (x *ThreadUpdated) GetReplyCount() int64 {
+	if x != nil {
+		return x.ReplyCount
+	}
+	return 0
+}
+
+func (x *ThreadUpdated) GetLastReplyAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastReplyAt
+	}
+	return nil
+}
+
+// Mention is delivered only to the mentioned user: to their StreamEvents and
+// Session streams, whatever chats a session follows, and to their ConnectChat
+// streams of the chat.
+// It is sent when a message mentions the user, on send or on edit.
+type Mention struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mention) Reset() {
+	*x = Mention{}
+	mi := &file_chat_v1_chat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mention) ProtoMessage() {}
+
+func (x *Mention) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mention.ProtoReflect.Descriptor instead.
+func (*Mention) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Mention) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// MemberJoined is delivered to the chat, including the new member's own
+// StreamEvents stream, which starts carrying the chat from then on.
+type MemberJoined struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberJoined) Reset() {
+	*x = MemberJoined{}
+	mi := &file_chat_v1_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberJoined) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberJoined) ProtoMessage() {}
+
+func (x *MemberJoined) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberJoined.ProtoReflect.Descriptor instead.
+func (*MemberJoined) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MemberJoined) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// MemberLeft is the last event of the chat a leaving member receives over
+// StreamEvents.
+type MemberLeft struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberLeft) Reset() {
+	*x = MemberLeft{}
+	mi := &file_chat_v1_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberLeft) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberLeft) ProtoMessage() {}
+
+func (x *MemberLeft) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberLeft.ProtoReflect.Descriptor instead.
+func (*MemberLeft) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MemberLeft) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Pin is a message pinned to the top of its chat.
+type Pin struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	PinnedBy      int64                  `protobuf:"varint,2,opt,name=pinned_by,json=pinnedBy,proto3" json:"pinned_by,omitempty"`
+	PinnedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=pinned_at,json=pinnedAt,proto3" json:"pinned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pin) Reset() {
+	*x = Pin{}
+	mi := &file_chat_v1_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pin) ProtoMessage() {}
+
+func (x *Pin) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pin.ProtoReflect.Descriptor instead.
+func (*Pin) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Pin) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *Pin) GetPinnedBy() int64 {
+	if x != nil {
+		return x.PinnedBy
+	}
+	return 0
+}
+
+func (x *Pin) GetPinnedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PinnedAt
+	}
+	return nil
+}
+
+// MessageUnpinned is also sent when a pinned message is deleted, with the
+// deleting user as unpinned_by, and when one expires, with unpinned_by unset.
+type MessageUnpinned struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UnpinnedBy    int64                  `protobuf:"varint,2,opt,name=unpinned_by,json=unpinnedBy,proto3" json:"unpinned_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageUnpinned) Reset() {
+	*x = MessageUnpinned{}
+	mi := &file_chat_v1_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageUnpinned) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageUnpinned) ProtoMessage() {}
+
+func (x *MessageUnpinned) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageUnpinned.ProtoReflect.Descriptor instead.
+func (*MessageUnpinned) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MessageUnpinned) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *MessageUnpinned) GetUnpinnedBy() int64 {
+	if x != nil {
+		return x.UnpinnedBy
+	}
+	return 0
+}
+
+// RetentionChanged reports a new retention policy of the chat. Messages that
+// expire under it arrive as message_deleted events.
+type RetentionChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Retention     *Retention             `protobuf:"bytes,1,opt,name=retention,proto3" json:"retention,omitempty"`
+	ChangedBy     int64                  `protobuf:"varint,2,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetentionChanged) Reset() {
+	*x = RetentionChanged{}
+	mi := &file_chat_v1_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetentionChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionChanged) ProtoMessage() {}
+
+func (x *RetentionChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetentionChanged.ProtoReflect.Descriptor instead.
+func (*RetentionChanged) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RetentionChanged) GetRetention() *Retention {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *RetentionChanged) GetChangedBy() int64 {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return 0
+}
+
+type MessageDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageDeleted) Reset() {
+	*x = MessageDeleted{}
+	mi := &file_chat_v1_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageDeleted) ProtoMessage() {}
+
+func (x *MessageDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageDeleted.ProtoReflect.Descriptor instead.
+func (*MessageDeleted) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MessageDeleted) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *MessageDeleted) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+// ReadReceipt reports that a member has read the chat up to a message.
+type ReadReceipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MessageId     int64                  `protobuf:"varint,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Seq           int64                  `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadReceipt) Reset() {
+	*x = ReadReceipt{}
+	mi := &file_chat_v1_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadReceipt) ProtoMessage() {}
+
+func (x *ReadReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadReceipt.ProtoReflect.Descriptor instead.
+func (*ReadReceipt) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReadReceipt) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReadReceipt) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *ReadReceipt) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ReadReceipt) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
+}
+
+// Typing reports that a member started or stopped typing. Typing events are
+// not stored; a started indicator lapses at expires_at unless renewed.
+type Typing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Typing        bool                   `protobuf:"varint,2,opt,name=typing,proto3" json:"typing,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Typing) Reset() {
+	*x = Typing{}
+	mi := &file_chat_v1_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Typing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Typing) ProtoMessage() {}
+
+func (x *Typing) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Typing.ProtoReflect.Descriptor instead.
+func (*Typing) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Typing) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Typing) GetTyping() bool {
+	if x != nil {
+		return x.Typing
+	}
+	return false
+}
+
+func (x *Typing) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// ReactionsChanged carries the full set of reaction counts of a message.
+type ReactionsChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Reactions     []*ReactionCount       `protobuf:"bytes,2,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionsChanged) Reset() {
+	*x = ReactionsChanged{}
+	mi := &file_chat_v1_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionsChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionsChanged) ProtoMessage() {}
+
+func (x *ReactionsChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionsChanged.ProtoReflect.Descriptor instead.
+func (*ReactionsChanged) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReactionsChanged) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *ReactionsChanged) GetReactions() []*ReactionCount {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type CreateChatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Public chats can be joined with JoinChat by anyone.
+	Public        bool `protobuf:"varint,2,opt,name=public,proto3" json:"public,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChatRequest) Reset() {
+	*x = CreateChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChatRequest) ProtoMessage() {}
+
+func (x *CreateChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChatRequest.ProtoReflect.Descriptor instead.
+func (*CreateChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateChatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateChatRequest) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+type CreateChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chat          *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChatResponse) Reset() {
+	*x = CreateChatResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChatResponse) ProtoMessage() {}
+
+func (x *CreateChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChatResponse.ProtoReflect.Descriptor instead.
+func (*CreateChatResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateChatResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+type GetChatListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chats         []*Chat                `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChatListResponse) Reset() {
+	*x = GetChatListResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatListResponse) ProtoMessage() {}
+
+func (x *GetChatListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatListResponse.ProtoReflect.Descriptor instead.
+func (*GetChatListResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetChatListResponse) GetChats() []*Chat {
+	if x != nil {
+		return x.Chats
+	}
+	return nil
+}
+
+type Chat struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// For direct chats, the name of the other participant.
+	Name string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind ChatKind `protobuf:"varint,3,opt,name=kind,proto3,enum=chatgrpc.v1.ChatKind" json:"kind,omitempty"`
+	// The other participant of a direct chat.
+	PeerUserId int64 `protobuf:"varint,4,opt,name=peer_user_id,json=peerUserId,proto3" json:"peer_user_id,omitempty"`
+	// Set in GetChatList only. Deleted and expired messages are not counted.
+	UnreadCount   int64      `protobuf:"varint,5,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	LastMessage   *Message   `protobuf:"bytes,6,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`
+	Retention     *Retention `protobuf:"bytes,7,opt,name=retention,proto3" json:"retention,omitempty"`
+	Public        bool       `protobuf:"varint,8,opt,name=public,proto3" json:"public,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chat) Reset() {
+	*x = Chat{}
+	mi := &file_chat_v1_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chat) ProtoMessage() {}
+
+func (x *Chat) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
+func (*Chat) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Chat) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Chat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Chat) GetKind() ChatKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ChatKind_CHAT_KIND_UNSPECIFIED
+}
+
+func (x *Chat) GetPeerUserId() int64 {
+	if x != nil {
+		return x.PeerUserId
+	}
+	return 0
+}
+
+func (x *Chat) GetUnreadCount() int64 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+func (x *Chat) GetLastMessage() *Message {
+	if x != nil {
+		return x.LastMessage
+	}
+	return nil
+}
+
+func (x *Chat) GetRetention() *Retention {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *Chat) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+type Retention struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Mode  RetentionMode          `protobuf:"varint,1,opt,name=mode,proto3,enum=chatgrpc.v1.RetentionMode" json:"mode,omitempty"`
+	// RETENTION_MODE_DAYS only, between 1 and 3650.
+	Days int32 `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	// RETENTION_MODE_DISAPPEARING only, between 5 seconds and 7 days.
+	TtlSeconds    int64 `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Retention) Reset() {
+	*x = Retention{}
+	mi := &file_chat_v1_chat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Retention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Retention) ProtoMessage() {}
+
+func (x *Retention) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Retention.ProtoReflect.Descriptor instead.
+func (*Retention) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Retention) GetMode() RetentionMode {
+	if x != nil {
+		return x.Mode
+	}
+	return RetentionMode_RETENTION_MODE_UNSPECIFIED
+}
+
+func (x *Retention) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *Retention) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type SetRetentionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Retention     *Retention             `protobuf:"bytes,2,opt,name=retention,proto3" json:"retention,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRetentionRequest) Reset() {
+	*x = SetRetentionRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRetentionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRetentionRequest) ProtoMessage() {}
+
+func (x *SetRetentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRetentionRequest.ProtoReflect.Descriptor instead.
+func (*SetRetentionRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetRetentionRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *SetRetentionRequest) GetRetention() *Retention {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+type ExportChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportChatRequest) Reset() {
+	*x = ExportChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChatRequest) ProtoMessage() {}
+
+func (x *ExportChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChatRequest.ProtoReflect.Descriptor instead.
+func (*ExportChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ExportChatRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+// ExportChatResponse carries the next piece of the export, an NDJSON document
+// described in chatService/internal/lib/chatexport. Chunks need not end on
+// line boundaries; concatenated in order they form the whole export. Users
+// are exported without their emails.
+type ExportChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportChatResponse) Reset() {
+	*x = ExportChatResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChatResponse) ProtoMessage() {}
+
+func (x *ExportChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChatResponse.ProtoReflect.Descriptor instead.
+func (*ExportChatResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ExportChatResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// ImportChatRequest carries the next piece of an export in the ExportChat
+// format. Only exports with user emails, such as operator dumps, can be
+// imported. Sending the same export again resumes an interrupted import.
+type ImportChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportChatRequest) Reset() {
+	*x = ImportChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportChatRequest) ProtoMessage() {}
+
+func (x *ImportChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportChatRequest.ProtoReflect.Descriptor instead.
+func (*ImportChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ImportChatRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type ImportChatResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Chat  *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	// Set when an earlier run already completed this import.
+	AlreadyImported  bool  `protobuf:"varint,2,opt,name=already_imported,json=alreadyImported,proto3" json:"already_imported,omitempty"`
+	ImportedMessages int32 `protobuf:"varint,3,opt,name=imported_messages,json=importedMessages,proto3" json:"imported_messages,omitempty"`
+	// Messages an earlier, interrupted run had already imported.
+	SkippedMessages int32 `protobuf:"varint,4,opt,name=skipped_messages,json=skippedMessages,proto3" json:"skipped_messages,omitempty"`
+	// Attachments whose content was neither in the export nor available in
+	// this environment.
+	MissingAttachments int32 `protobuf:"varint,5,opt,name=missing_attachments,json=missingAttachments,proto3" json:"missing_attachments,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ImportChatResponse) Reset() {
+	*x = ImportChatResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportChatResponse) ProtoMessage() {}
+
+func (x *ImportChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportChatResponse.ProtoReflect.Descriptor instead.
+func (*ImportChatResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ImportChatResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+func (x *ImportChatResponse) GetAlreadyImported() bool {
+	if x != nil {
+		return x.AlreadyImported
+	}
+	return false
+}
+
+func (x *ImportChatResponse) GetImportedMessages() int32 {
+	if x != nil {
+		return x.ImportedMessages
+	}
+	return 0
+}
+
+func (x *ImportChatResponse) GetSkippedMessages() int32 {
+	if x != nil {
+		return x.SkippedMessages
+	}
+	return 0
+}
+
+func (x *ImportChatResponse) GetMissingAttachments() int32 {
+	if x != nil {
+		return x.MissingAttachments
+	}
+	return 0
+}
+
+type GetOrCreateDirectChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerUserId    int64                  `protobuf:"varint,1,opt,name=peer_user_id,json=peerUserId,proto3" json:"peer_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrCreateDirectChatRequest) Reset() {
+	*x = GetOrCreateDirectChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrCreateDirectChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrCreateDirectChatRequest) ProtoMessage() {}
+
+func (x *GetOrCreateDirectChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrCreateDirectChatRequest.ProtoReflect.Descriptor instead.
+func (*GetOrCreateDirectChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetOrCreateDirectChatRequest) GetPeerUserId() int64 {
+	if x != nil {
+		return x.PeerUserId
+	}
+	return 0
+}
+
+type GetOrCreateDirectChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chat          *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrCreateDirectChatResponse) Reset() {
+	*x = GetOrCreateDirectChatResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrCreateDirectChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrCreateDirectChatResponse) ProtoMessage() {}
+
+func (x *GetOrCreateDirectChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrCreateDirectChatResponse.ProtoReflect.Descriptor instead.
+func (*GetOrCreateDirectChatResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetOrCreateDirectChatResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+type ConnectChatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// When set, messages after this id are replayed before live delivery starts.
+	LastSeenMessageId int64 `protobuf:"varint,2,opt,name=last_seen_message_id,json=lastSeenMessageId,proto3" json:"last_seen_message_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ConnectChatRequest) Reset() {
+	*x = ConnectChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectChatRequest) ProtoMessage() {}
+
+func (x *ConnectChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectChatRequest.ProtoReflect.Descriptor instead.
+func (*ConnectChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ConnectChatRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConnectChatRequest) GetLastSeenMessageId() int64 {
+	if x != nil {
+		return x.LastSeenMessageId
+	}
+	return 0
+}
+
+type SendMessageRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Ignored: the sender is the authenticated caller.
+	//
+	// Deprecated: Marked as deprecated in chat/v1/chat.proto.
+	SenderId int64  `protobuf:"varint,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	Text     string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	// Replies to a reply join the thread of its root.
+	ReplyToMessageId int64 `protobuf:"varint,4,opt,name=reply_to_message_id,json=replyToMessageId,proto3" json:"reply_to_message_id,omitempty"`
+	// Unsent uploads of the caller to the same chat. The text may be empty
+	// when attachments are given.
+	AttachmentIds []int64 `protobuf:"varint,5,rep,packed,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SendMessageRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in chat/v1/chat.proto.
+func (x *SendMessageRequest) GetSenderId() int64 {
+	if x != nil {
+		return x.SenderId
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetReplyToMessageId() int64 {
+	if x != nil {
+		return x.ReplyToMessageId
+	}
+	return 0
+}
+
+func (x *SendMessageRequest) GetAttachmentIds() []int64 {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+type GetMessagesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Message id to page from, exclusive. Zero starts from the newest message.
+	Cursor int64 `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Unspecified is treated as DIRECTION_OLDER.
+	Direction     Direction `protobuf:"varint,3,opt,name=direction,proto3,enum=chatgrpc.v1.Direction" json:"direction,omitempty"`
+	Limit         int32     `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessagesRequest) Reset() {
+	*x = GetMessagesRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessagesRequest) ProtoMessage() {}
+
+func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessagesRequest.ProtoReflect.Descriptor instead.
+func (*GetMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetMessagesRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *GetMessagesRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *GetMessagesRequest) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIRECTION_UNSPECIFIED
+}
+
+func (x *GetMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Messages []*Message `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	// Cursor for the next page in the same direction, zero when there is none.
+	NextCursor    int64 `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessagesResponse) Reset() {
+	*x = GetMessagesResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessagesResponse) ProtoMessage() {}
+
+func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessagesResponse.ProtoReflect.Descriptor instead.
+func (*GetMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *GetMessagesResponse) GetNextCursor() int64 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+type Member struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	JoinedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	Role     Role                   `protobuf:"varint,3,opt,name=role,proto3,enum=chatgrpc.v1.Role" json:"role,omitempty"`
+	// The handle the member is mentioned by, empty if none was set.
+	Handle        string `protobuf:"bytes,4,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_chat_v1_chat_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Member) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Member) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+func (x *Member) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *Member) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+type AddMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMemberRequest) Reset() {
+	*x = AddMemberRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberRequest) ProtoMessage() {}
+
+func (x *AddMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddMemberRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AddMemberRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *AddMemberRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type RemoveMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RemoveMemberRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *RemoveMemberRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type JoinChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinChatRequest) Reset() {
+	*x = JoinChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinChatRequest) ProtoMessage() {}
+
+func (x *JoinChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinChatRequest.ProtoReflect.Descriptor instead.
+func (*JoinChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *JoinChatRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+type LeaveChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveChatRequest) Reset() {
+	*x = LeaveChatRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveChatRequest) ProtoMessage() {}
+
+func (x *LeaveChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveChatRequest.ProtoReflect.Descriptor instead.
+func (*LeaveChatRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LeaveChatRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+type ListMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersRequest) Reset() {
+	*x = ListMembersRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersRequest) ProtoMessage() {}
+
+func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListMembersRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListMembersRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+type ListMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersResponse) Reset() {
+	*x = ListMembersResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersResponse) ProtoMessage() {}
+
+func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListMembersResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListMembersResponse) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type SetMemberRoleRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// ROLE_OWNER cannot be assigned.
+	Role          Role `protobuf:"varint,3,opt,name=role,proto3,enum=chatgrpc.v1.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMemberRoleRequest) Reset() {
+	*x = SetMemberRoleRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMemberRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMemberRoleRequest) ProtoMessage() {}
+
+func (x *SetMemberRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMemberRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetMemberRoleRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SetMemberRoleRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *SetMemberRoleRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetMemberRoleRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+type EditMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageRequest) Reset() {
+	*x = EditMessageRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRequest) ProtoMessage() {}
+
+func (x *EditMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *EditMessageRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *EditMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type DeleteMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageRequest) Reset() {
+	*x = DeleteMessageRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageRequest) ProtoMessage() {}
+
+func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeleteMessageRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type PinMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageRequest) Reset() {
+	*x = PinMessageRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageRequest) ProtoMessage() {}
+
+func (x *PinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageRequest.ProtoReflect.Descriptor instead.
+func (*PinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PinMessageRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type UnpinMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinMessageRequest) Reset() {
+	*x = UnpinMessageRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMessageRequest) ProtoMessage() {}
+
+func (x *UnpinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMessageRequest.ProtoReflect.Descriptor instead.
+func (*UnpinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UnpinMessageRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type ListPinnedMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedMessagesRequest) Reset() {
+	*x = ListPinnedMessagesRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedMessagesRequest) ProtoMessage() {}
+
+func (x *ListPinnedMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListPinnedMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListPinnedMessagesRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+type ListPinnedMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most recently pinned first.
+	Pins          []*Pin `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedMessagesResponse) Reset() {
+	*x = ListPinnedMessagesResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedMessagesResponse) ProtoMessage() {}
+
+func (x *ListPinnedMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListPinnedMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListPinnedMessagesResponse) GetPins() []*Pin {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+type AddReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,2,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReactionRequest) Reset() {
+	*x = AddReactionRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReactionRequest) ProtoMessage() {}
+
+func (x *AddReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReactionRequest.ProtoReflect.Descriptor instead.
+func (*AddReactionRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AddReactionRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *AddReactionRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type RemoveReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     int64                  `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,2,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveReactionRequest) Reset() {
+	*x = RemoveReactionRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionRequest) ProtoMessage() {}
+
+func (x *RemoveReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionRequest.ProtoReflect.Descriptor instead.
+func (*RemoveReactionRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RemoveReactionRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *RemoveReactionRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type GetThreadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Any message of the thread; replies resolve to their root.
+	MessageId     int64     `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Cursor        int64     `protobuf:"varint,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Direction     Direction `protobuf:"varint,3,opt,name=direction,proto3,enum=chatgrpc.v1.Direction" json:"direction,omitempty"`
+	Limit         int32     `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadRequest) Reset() {
+	*x = GetThreadRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadRequest) ProtoMessage() {}
+
+func (x *GetThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadRequest.ProtoReflect.Descriptor instead.
+func (*GetThreadRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetThreadRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *GetThreadRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *GetThreadRequest) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_DIRECTION_UNSPECIFIED
+}
+
+func (x *GetThreadRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetThreadResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Root  *Message               `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	// Newest first.
+	Replies       []*Message `protobuf:"bytes,2,rep,name=replies,proto3" json:"replies,omitempty"`
+	NextCursor    int64      `protobuf:"varint,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadResponse) Reset() {
+	*x = GetThreadResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadResponse) ProtoMessage() {}
+
+func (x *GetThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadResponse.ProtoReflect.Descriptor instead.
+func (*GetThreadResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetThreadResponse) GetRoot() *Message {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *GetThreadResponse) GetReplies() []*Message {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+func (x *GetThreadResponse) GetNextCursor() int64 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+type MarkReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     int64                  `protobuf:"varint,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadRequest) ProtoMessage() {}
+
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *MarkReadRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *MarkReadRequest) GetMessageId() int64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+// NotifyTypingRequest is sent while the user types, repeated every few seconds
+// to keep the indicator alive. typing = false clears it right away.
+type NotifyTypingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Typing        bool                   `protobuf:"varint,2,opt,name=typing,proto3" json:"typing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyTypingRequest) Reset() {
+	*x = NotifyTypingRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyTypingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyTypingRequest) ProtoMessage() {}
+
+func (x *NotifyTypingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyTypingRequest.ProtoReflect.Descriptor instead.
+func (*NotifyTypingRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *NotifyTypingRequest) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *NotifyTypingRequest) GetTyping() bool {
+	if x != nil {
+		return x.Typing
+	}
+	return false
+}
+
+type Presence struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status PresenceStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=chatgrpc.v1.PresenceStatus" json:"status,omitempty"`
+	// When the user's last stream closed. Only set for offline users.
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Presence) Reset() {
+	*x = Presence{}
+	mi := &file_chat_v1_chat_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Presence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Presence) ProtoMessage() {}
+
+func (x *Presence) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Presence.ProtoReflect.Descriptor instead.
+func (*Presence) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *Presence) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Presence) GetStatus() PresenceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PresenceStatus_PRESENCE_STATUS_UNSPECIFIED
+}
+
+func (x *Presence) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+// SetPresenceRequest switches the caller between online and away. It requires
+// an open stream.
+type SetPresenceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Either PRESENCE_STATUS_ONLINE or PRESENCE_STATUS_AWAY.
+	Status        PresenceStatus `protobuf:"varint,1,opt,name=status,proto3,enum=chatgrpc.v1.PresenceStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPresenceRequest) Reset() {
+	*x = SetPresenceRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPresenceRequest) ProtoMessage() {}
+
+func (x *SetPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPresenceRequest.ProtoReflect.Descriptor instead.
+func (*SetPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SetPresenceRequest) GetStatus() PresenceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PresenceStatus_PRESENCE_STATUS_UNSPECIFIED
+}
+
+type GetPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []int64                `protobuf:"varint,1,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceRequest) Reset() {
+	*x = GetPresenceRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceRequest) ProtoMessage() {}
+
+func (x *GetPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceRequest.ProtoReflect.Descriptor instead.
+func (*GetPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetPresenceRequest) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type GetPresenceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order of the requested user ids.
+	Presences     []*Presence `protobuf:"bytes,1,rep,name=presences,proto3" json:"presences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceResponse) Reset() {
+	*x = GetPresenceResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceResponse) ProtoMessage() {}
+
+func (x *GetPresenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceResponse.ProtoReflect.Descriptor instead.
+func (*GetPresenceResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetPresenceResponse) GetPresences() []*Presence {
+	if x != nil {
+		return x.Presences
+	}
+	return nil
+}
+
+// WatchPresenceRequest streams the current presence of each user followed by
+// their changes. The stream ends with RESOURCE_EXHAUSTED when the client falls
+// behind, and with PERMISSION_DENIED once the caller no longer shares a chat
+// with one of the users; watching again starts over from the current state.
+type WatchPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []int64                `protobuf:"varint,1,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPresenceRequest) Reset() {
+	*x = WatchPresenceRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPresenceRequest) ProtoMessage() {}
+
+func (x *WatchPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPresenceRequest.ProtoReflect.Descriptor instead.
+func (*WatchPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *WatchPresenceRequest) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// SessionRequest is one client action on a Session stream. Every request is
+// answered with a SessionAck carrying the same request_id.
+type SessionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Chosen by the client to match acks to requests.
+	RequestId int64 `protobuf:"varint,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Types that are valid to be assigned to Action:
+	//
+	//	*SessionRequest_Subscribe
+	//	*SessionRequest_Unsubscribe
+	//	*SessionRequest_SendMessage
+	//	*SessionRequest_Typing
+	//	*SessionRequest_MarkRead
+	Action        isSessionRequest_Action `protobuf_oneof:"action"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRequest) Reset() {
+	*x = SessionRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRequest) ProtoMessage() {}
+
+func (x *SessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRequest.ProtoReflect.Descriptor instead.
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SessionRequest) GetRequestId() int64 {
+	if x != nil {
+		return x.RequestId
+	}
+	return 0
+}
+
+func (x *SessionRequest) GetAction() isSessionRequest_Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetSubscribe() *SubscribeChat {
+	if x != nil {
+		if x, ok := x.Action.(*SessionRequest_Subscribe); ok {
+			return x.Subscribe
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetUnsubscribe() *UnsubscribeChat {
+	if x != nil {
+		if x, ok := x.Action.(*SessionRequest_Unsubscribe); ok {
+			return x.Unsubscribe
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetSendMessage() *SendMessageRequest {
+	if x != nil {
+		if x, ok := x.Action.(*SessionRequest_SendMessage); ok {
+			return x.SendMessage
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetTyping() *NotifyTypingRequest {
+	if x != nil {
+		if x, ok := x.Action.(*SessionRequest_Typing); ok {
+			return x.Typing
+		}
+	}
+	return nil
+}
+
+func (x *SessionRequest) GetMarkRead() *MarkReadRequest {
+	if x != nil {
+		if x, ok := x.Action.(*SessionRequest_MarkRead); ok {
+			return x.MarkRead
+		}
+	}
+	return nil
+}
+
+type isSessionRequest_Action interface {
+	isSessionRequest_Action()
+}
+
+type SessionRequest_Subscribe struct {
+	Subscribe *SubscribeChat `protobuf:"bytes,2,opt,name=subscribe,proto3,oneof"`
+}
+
+type SessionRequest_Unsubscribe struct {
+	Unsubscribe *UnsubscribeChat `protobuf:"bytes,3,opt,name=unsubscribe,proto3,oneof"`
+}
+
+type SessionRequest_SendMessage struct {
+	SendMessage *SendMessageRequest `protobuf:"bytes,4,opt,name=send_message,json=sendMessage,proto3,oneof"`
+}
+
+type SessionRequest_Typing struct {
+	Typing *NotifyTypingRequest `protobuf:"bytes,5,opt,name=typing,proto3,oneof"`
+}
+
+type SessionRequest_MarkRead struct {
+	MarkRead *MarkReadRequest `protobuf:"bytes,6,opt,name=mark_read,json=markRead,proto3,oneof"`
+}
+
+func (*SessionRequest_Subscribe) isSessionRequest_Action() {}
+
+func (*SessionRequest_Unsubscribe) isSessionRequest_Action() {}
+
+func (*SessionRequest_SendMessage) isSessionRequest_Action() {}
+
+func (*SessionRequest_Typing) isSessionRequest_Action() {}
+
+func (*SessionRequest_MarkRead) isSessionRequest_Action() {}
+
+// SubscribeChat starts delivering the events of a chat, like ConnectChat.
+type SubscribeChat struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// When set, messages after this id are replayed before live delivery starts.
+	LastSeenMessageId int64 `protobuf:"varint,2,opt,name=last_seen_message_id,json=lastSeenMessageId,proto3" json:"last_seen_message_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SubscribeChat) Reset() {
+	*x = SubscribeChat{}
+	mi := &file_chat_v1_chat_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeChat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeChat) ProtoMessage() {}
+
+func (x *SubscribeChat) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeChat.ProtoReflect.Descriptor instead.
+func (*SubscribeChat) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SubscribeChat) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *SubscribeChat) GetLastSeenMessageId() int64 {
+	if x != nil {
+		return x.LastSeenMessageId
+	}
+	return 0
+}
+
+type UnsubscribeChat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeChat) Reset() {
+	*x = UnsubscribeChat{}
+	mi := &file_chat_v1_chat_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeChat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeChat) ProtoMessage() {}
+
+func (x *UnsubscribeChat) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeChat.ProtoReflect.Descriptor instead.
+func (*UnsubscribeChat) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *UnsubscribeChat) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+type SessionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*SessionResponse_Event
+	//	*SessionResponse_Ack
+	Payload       isSessionResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionResponse) Reset() {
+	*x = SessionResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionResponse) ProtoMessage() {}
+
+func (x *SessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionResponse.ProtoReflect.Descriptor instead.
+func (*SessionResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *SessionResponse) GetPayload() isSessionResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *SessionResponse) GetEvent() *ChatEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*SessionResponse_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *SessionResponse) GetAck() *SessionAck {
+	if x != nil {
+		if x, ok := x.Payload.(*SessionResponse_Ack); ok {
+			return x.Ack
+		}
+	}
+	return nil
+}
+
+type isSessionResponse_Payload interface {
+	isSessionResponse_Payload()
+}
+
+type SessionResponse_Event struct {
+	Event *ChatEvent `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
+}
+
+type SessionResponse_Ack struct {
+	Ack *SessionAck `protobuf:"bytes,2,opt,name=ack,proto3,oneof"`
+}
+
+func (*SessionResponse_Event) isSessionResponse_Payload() {}
+
+func (*SessionResponse_Ack) isSessionResponse_Payload() {}
+
+// SessionAck reports the outcome of a SessionRequest. A failed request does
+// not end the session.
+type SessionAck struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId int64                  `protobuf:"varint,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// A google.rpc.Code; zero means the request succeeded.
+	Code  int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// The stored message, for send_message.
+	Message       *Message `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionAck) Reset() {
+	*x = SessionAck{}
+	mi := &file_chat_v1_chat_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionAck) ProtoMessage() {}
+
+func (x *SessionAck) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionAck.ProtoReflect.Descriptor instead.
+func (*SessionAck) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *SessionAck) GetRequestId() int64 {
+	if x != nil {
+		return x.RequestId
+	}
+	return 0
+}
+
+func (x *SessionAck) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *SessionAck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SessionAck) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// SearchMessagesRequest searches the chats the caller is a member of. Unset
+// filters are ignored.
+type SearchMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Web search syntax: words, "quoted phrases", OR and -excluded words.
+	Query    string  `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	ChatIds  []int64 `protobuf:"varint,2,rep,packed,name=chat_ids,json=chatIds,proto3" json:"chat_ids,omitempty"`
+	SenderId int64   `protobuf:"varint,3,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	// Inclusive.
+	From *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	// Exclusive.
+	To *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	// next_cursor of the previous page, zero for the first page.
+	Cursor        int64 `protobuf:"varint,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesRequest) Reset() {
+	*x = SearchMessagesRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesRequest) ProtoMessage() {}
+
+func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SearchMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SearchMessagesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetChatIds() []int64 {
+	if x != nil {
+		return x.ChatIds
+	}
+	return nil
+}
+
+func (x *SearchMessagesRequest) GetSenderId() int64 {
+	if x != nil {
+		return x.SenderId
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *SearchMessagesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *SearchMessagesRequest) GetCursor() int64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// HTML excerpt of the text with matches wrapped in <mark></mark>. The
+	// text itself is escaped.
+	Snippet       string `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_chat_v1_chat_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SearchResult) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type SearchMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Results       []*SearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	NextCursor    int64           `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesResponse) Reset() {
+	*x = SearchMessagesResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesResponse) ProtoMessage() {}
+
+func (x *SearchMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesResponse.ProtoReflect.Descriptor instead.
+func (*SearchMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *SearchMessagesResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchMessagesResponse) GetNextCursor() int64 {
+	if x != nil {
+		return x.NextCursor
+	}
+	return 0
+}
+
+// UploadAttachmentRequest is one message of an upload: the first carries the
+// info, every following one a chunk of the content.
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadAttachmentRequest_Info
+	//	*UploadAttachmentRequest_Chunk
+	Data          isUploadAttachmentRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *UploadAttachmentRequest) GetData() isUploadAttachmentRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetInfo() *UploadAttachmentInfo {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Data interface {
+	isUploadAttachmentRequest_Data()
+}
+
+type UploadAttachmentRequest_Info struct {
+	Info *UploadAttachmentInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Info) isUploadAttachmentRequest_Data() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Data() {}
+
+type UploadAttachmentInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Chat the attachment will be sent to.
+	ChatId        int64  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Filename      string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Message) Reset() {
-	*x = Message{}
-	mi := &file_chat_v1_chat_proto_msgTypes[0]
+func (x *UploadAttachmentInfo) Reset() {
+	*x = UploadAttachmentInfo{}
+	mi := &file_chat_v1_chat_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Message) String() string {
+func (x *UploadAttachmentInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Message) ProtoMessage() {}
+func (*UploadAttachmentInfo) ProtoMessage() {}
 
-func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_v1_chat_proto_msgTypes[0]
+func (x *UploadAttachmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,61 +4449,49 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Message.ProtoReflect.Descriptor instead.
-func (*Message) Descriptor() ([]byte, []int) {
-	return file_chat_v1_chat_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *Message) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
+// Deprecated: Use UploadAttachmentInfo.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentInfo) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *Message) GetChatId() int64 {
+func (x *UploadAttachmentInfo) GetChatId() int64 {
 	if x != nil {
 		return x.ChatId
 	}
 	return 0
 }
 
-func (x *Message) GetSenderId() int64 {
-	if x != nil {
-		return x.SenderId
-	}
-	return 0
-}
-
-func (x *Message) GetText() string {
+func (x *UploadAttachmentInfo) GetFilename() string {
 	if x != nil {
-		return x.Text
+		return x.Filename
 	}
 	return ""
 }
 
-type CreateChatRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+type DownloadAttachmentRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId int64                  `protobuf:"varint,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	// When set, the thumbnail of the attachment is downloaded instead.
+	ThumbnailId   int64 `protobuf:"varint,2,opt,name=thumbnail_id,json=thumbnailId,proto3" json:"thumbnail_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateChatRequest) Reset() {
-	*x = CreateChatRequest{}
-	mi := &file_chat_v1_chat_proto_msgTypes[1]
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateChatRequest) String() string {
+func (x *DownloadAttachmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateChatRequest) ProtoMessage() {}
+func (*DownloadAttachmentRequest) ProtoMessage() {}
 
-func (x *CreateChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_v1_chat_proto_msgTypes[1]
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -122,40 +4502,54 @@ func (x *CreateChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateChatRequest.ProtoReflect.Descriptor instead.
-func (*CreateChatRequest) Descriptor() ([]byte, []int) {
-	return file_chat_v1_chat_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *CreateChatRequest) GetName() string {
+func (x *DownloadAttachmentRequest) GetAttachmentId() int64 {
 	if x != nil {
-		return x.Name
+		return x.AttachmentId
 	}
-	return ""
+	return 0
 }
 
-type CreateChatResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chat          *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+func (x *DownloadAttachmentRequest) GetThumbnailId() int64 {
+	if x != nil {
+		return x.ThumbnailId
+	}
+	return 0
+}
+
+// DownloadAttachmentResponse mirrors UploadAttachmentRequest: the attachment,
+// or the thumbnail when one was requested, first, then the content in chunks.
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*DownloadAttachmentResponse_Attachment
+	//	*DownloadAttachmentResponse_Chunk
+	//	*DownloadAttachmentResponse_Thumbnail
+	Data          isDownloadAttachmentResponse_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateChatResponse) Reset() {
-	*x = CreateChatResponse{}
-	mi := &file_chat_v1_chat_proto_msgTypes[2]
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateChatResponse) String() string {
+func (x *DownloadAttachmentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateChatResponse) ProtoMessage() {}
+func (*DownloadAttachmentResponse) ProtoMessage() {}
 
-func (x *CreateChatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_v1_chat_proto_msgTypes[2]
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -166,40 +4560,95 @@ func (x *CreateChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateChatResponse.ProtoReflect.Descriptor instead.
-func (*CreateChatResponse) Descriptor() ([]byte, []int) {
-	return file_chat_v1_chat_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *CreateChatResponse) GetChat() *Chat {
+func (x *DownloadAttachmentResponse) GetData() isDownloadAttachmentResponse_Data {
 	if x != nil {
-		return x.Chat
+		return x.Data
 	}
 	return nil
 }
 
-type GetChatListResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chats         []*Chat                `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+func (x *DownloadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetThumbnail() *Thumbnail {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Thumbnail); ok {
+			return x.Thumbnail
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Data interface {
+	isDownloadAttachmentResponse_Data()
+}
+
+type DownloadAttachmentResponse_Attachment struct {
+	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Thumbnail struct {
+	Thumbnail *Thumbnail `protobuf:"bytes,3,opt,name=thumbnail,proto3,oneof"`
+}
+
+func (*DownloadAttachmentResponse_Attachment) isDownloadAttachmentResponse_Data() {}
+
+func (*DownloadAttachmentResponse_Chunk) isDownloadAttachmentResponse_Data() {}
+
+func (*DownloadAttachmentResponse_Thumbnail) isDownloadAttachmentResponse_Data() {}
+
+// Notification is an entry of the caller's inbox; currently always a mention.
+type Notification struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChatId    int64                  `protobuf:"varint,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Message   *Message               `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Unset while unread.
+	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChatListResponse) Reset() {
-	*x = GetChatListResponse{}
-	mi := &file_chat_v1_chat_proto_msgTypes[3]
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_chat_v1_chat_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChatListResponse) String() string {
+func (x *Notification) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChatListResponse) ProtoMessage() {}
+func (*Notification) ProtoMessage() {}
 
-func (x *GetChatListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_v1_chat_proto_msgTypes[3]
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -210,41 +4659,71 @@ func (x *GetChatListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChatListResponse.ProtoReflect.Descriptor instead.
-func (*GetChatListResponse) Descriptor() ([]byte, []int) {
-	return file_chat_v1_chat_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *GetChatListResponse) GetChats() []*Chat {
+func (x *Notification) GetId() int64 {
 	if x != nil {
-		return x.Chats
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Notification) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *Notification) GetMessage() *Message {
+	if x != nil {
+		return x.Message
 	}
 	return nil
 }
 
-type Chat struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+func (x *Notification) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Notification) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
+}
+
+type ListNotificationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// next_cursor of the previous page, zero for the first page.
+	Cursor        int64 `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	UnreadOnly    bool  `protobuf:"varint,3,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Chat) Reset() {
-	*x = Chat{}
-	mi := &file_chat_v1_chat_proto_msgTypes[4]
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Chat) String() string {
+func (x *ListNotificationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Chat) ProtoMessage() {}
+func (*ListNotificationsRequest) ProtoMessage() {}
 
-func (x *Chat) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_v1_chat_proto_msgTypes[4]
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,47 +4734,56 @@ func (x *Chat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
-func (*Chat) Descriptor() ([]byte, []int) {
-	return file_chat_v1_chat_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *Chat) GetId() int64 {
+func (x *ListNotificationsRequest) GetCursor() int64 {
 	if x != nil {
-		return x.Id
+		return x.Cursor
 	}
 	return 0
 }
 
-func (x *Chat) GetName() string {
+func (x *ListNotificationsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Name
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type ConnectChatRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *ListNotificationsRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+type ListNotificationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Notifications []*Notification `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	NextCursor    int64           `protobuf:"varint,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConnectChatRequest) Reset() {
-	*x = ConnectChatRequest{}
-	mi := &file_chat_v1_chat_proto_msgTypes[5]
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_chat_v1_chat_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConnectChatRequest) String() string {
+func (x *ListNotificationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectChatRequest) ProtoMessage() {}
+func (*ListNotificationsResponse) ProtoMessage() {}
 
-func (x *ConnectChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_v1_chat_proto_msgTypes[5]
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -306,42 +4794,47 @@ func (x *ConnectChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectChatRequest.ProtoReflect.Descriptor instead.
-func (*ConnectChatRequest) Descriptor() ([]byte, []int) {
-	return file_chat_v1_chat_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *ConnectChatRequest) GetId() int64 {
+func (x *ListNotificationsResponse) GetNotifications() []*Notification {
 	if x != nil {
-		return x.Id
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetNextCursor() int64 {
+	if x != nil {
+		return x.NextCursor
 	}
 	return 0
 }
 
-type SendMessageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	SenderId      int64                  `protobuf:"varint,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type MarkNotificationReadRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId int64                  `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *SendMessageRequest) Reset() {
-	*x = SendMessageRequest{}
-	mi := &file_chat_v1_chat_proto_msgTypes[6]
+func (x *MarkNotificationReadRequest) Reset() {
+	*x = MarkNotificationReadRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendMessageRequest) String() string {
+func (x *MarkNotificationReadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendMessageRequest) ProtoMessage() {}
+func (*MarkNotificationReadRequest) ProtoMessage() {}
 
-func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chat_v1_chat_proto_msgTypes[6]
+func (x *MarkNotificationReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,28 +4845,61 @@ func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
-func (*SendMessageRequest) Descriptor() ([]byte, []int) {
-	return file_chat_v1_chat_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use MarkNotificationReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkNotificationReadRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *SendMessageRequest) GetChatId() int64 {
+func (x *MarkNotificationReadRequest) GetNotificationId() int64 {
 	if x != nil {
-		return x.ChatId
+		return x.NotificationId
 	}
 	return 0
 }
 
-func (x *SendMessageRequest) GetSenderId() int64 {
+// SetHandleRequest sets the caller's handle, which is unique regardless of
+// case. Handles are made of letters, digits, '_', '-' and inner '.', and are
+// at most 32 characters long.
+type SetHandleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        string                 `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetHandleRequest) Reset() {
+	*x = SetHandleRequest{}
+	mi := &file_chat_v1_chat_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetHandleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetHandleRequest) ProtoMessage() {}
+
+func (x *SetHandleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chat_v1_chat_proto_msgTypes[73]
 	if x != nil {
-		return x.SenderId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *SendMessageRequest) GetText() string {
+// Deprecated: Use SetHandleRequest.ProtoReflect.Descriptor instead.
+func (*SetHandleRequest) Descriptor() ([]byte, []int) {
+	return file_chat_v1_chat_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *SetHandleRequest) GetHandle() string {
 	if x != nil {
-		return x.Text
+		return x.Handle
 	}
 	return ""
 }
@@ -382,33 +4908,391 @@ var File_chat_v1_chat_proto protoreflect.FileDescriptor
 
 const file_chat_v1_chat_proto_rawDesc = "" +
 	"\n" +
-	"\x12chat/v1/chat.proto\x12\vchatgrpc.v1\x1a\x1bgoogle/protobuf/empty.proto\"c\n" +
+	"\x12chat/v1/chat.proto\x12\vchatgrpc.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb6\x04\n" +
 	"\aMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\achat_id\x18\x02 \x01(\x03R\x06chatId\x12\x1b\n" +
 	"\tsender_id\x18\x03 \x01(\x03R\bsenderId\x12\x12\n" +
-	"\x04text\x18\x04 \x01(\tR\x04text\"'\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x10\n" +
+	"\x03seq\x18\x06 \x01(\x03R\x03seq\x127\n" +
+	"\tedited_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\x12\x18\n" +
+	"\adeleted\x18\b \x01(\bR\adeleted\x128\n" +
+	"\treactions\x18\t \x03(\v2\x1a.chatgrpc.v1.ReactionCountR\treactions\x12-\n" +
+	"\x13reply_to_message_id\x18\n" +
+	" \x01(\x03R\x10replyToMessageId\x12\x1f\n" +
+	"\vreply_count\x18\v \x01(\x03R\n" +
+	"replyCount\x12>\n" +
+	"\rlast_reply_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vlastReplyAt\x129\n" +
+	"\vattachments\x18\r \x03(\v2\x17.chatgrpc.v1.AttachmentR\vattachments\x12,\n" +
+	"\x12mentioned_user_ids\x18\x0e \x03(\x03R\x10mentionedUserIds\"\xd5\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05width\x18\x05 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x06 \x01(\x05R\x06height\x126\n" +
+	"\n" +
+	"thumbnails\x18\a \x03(\v2\x16.chatgrpc.v1.ThumbnailR\n" +
+	"thumbnails\"\x80\x01\n" +
+	"\tThumbnail\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\";\n" +
+	"\rReactionCount\x12\x14\n" +
+	"\x05emoji\x18\x01 \x01(\tR\x05emoji\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xfa\x06\n" +
+	"\tChatEvent\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12?\n" +
+	"\x0fmessage_created\x18\x02 \x01(\v2\x14.chatgrpc.v1.MessageH\x00R\x0emessageCreated\x12=\n" +
+	"\x0emessage_edited\x18\x03 \x01(\v2\x14.chatgrpc.v1.MessageH\x00R\rmessageEdited\x12F\n" +
+	"\x0fmessage_deleted\x18\x04 \x01(\v2\x1b.chatgrpc.v1.MessageDeletedH\x00R\x0emessageDeleted\x12L\n" +
+	"\x11reactions_changed\x18\x05 \x01(\v2\x1d.chatgrpc.v1.ReactionsChangedH\x00R\x10reactionsChanged\x12=\n" +
+	"\fread_receipt\x18\x06 \x01(\v2\x18.chatgrpc.v1.ReadReceiptH\x00R\vreadReceipt\x12-\n" +
+	"\x06typing\x18\a \x01(\v2\x13.chatgrpc.v1.TypingH\x00R\x06typing\x12@\n" +
+	"\rmember_joined\x18\b \x01(\v2\x19.chatgrpc.v1.MemberJoinedH\x00R\fmemberJoined\x12:\n" +
+	"\vmember_left\x18\t \x01(\v2\x17.chatgrpc.v1.MemberLeftH\x00R\n" +
+	"memberLeft\x120\n" +
+	"\amention\x18\n" +
+	" \x01(\v2\x14.chatgrpc.v1.MentionH\x00R\amention\x129\n" +
+	"\x0emessage_pinned\x18\v \x01(\v2\x10.chatgrpc.v1.PinH\x00R\rmessagePinned\x12I\n" +
+	"\x10message_unpinned\x18\f \x01(\v2\x1c.chatgrpc.v1.MessageUnpinnedH\x00R\x0fmessageUnpinned\x12L\n" +
+	"\x11retention_changed\x18\r \x01(\v2\x1d.chatgrpc.v1.RetentionChangedH\x00R\x10retentionChanged\x12C\n" +
+	"\x0ethread_updated\x18\x0e \x01(\v2\x1a.chatgrpc.v1.ThreadUpdatedH\x00R\rthreadUpdatedB\a\n" +
+	"\x05event\"\x98\x01\n" +
+	"\rThreadUpdated\x12&\n" +
+	"\x0froot_message_id\x18\x01 \x01(\x03R\rrootMessageId\x12\x1f\n" +
+	"\vreply_count\x18\x02 \x01(\x03R\n" +
+	"replyCount\x12>\n" +
+	"\rlast_reply_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vlastReplyAt\"9\n" +
+	"\aMention\x12.\n" +
+	"\amessage\x18\x01 \x01(\v2\x14.chatgrpc.v1.MessageR\amessage\"'\n" +
+	"\fMemberJoined\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"%\n" +
+	"\n" +
+	"MemberLeft\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x8b\x01\n" +
+	"\x03Pin\x12.\n" +
+	"\amessage\x18\x01 \x01(\v2\x14.chatgrpc.v1.MessageR\amessage\x12\x1b\n" +
+	"\tpinned_by\x18\x02 \x01(\x03R\bpinnedBy\x127\n" +
+	"\tpinned_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bpinnedAt\"Q\n" +
+	"\x0fMessageUnpinned\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x1f\n" +
+	"\vunpinned_by\x18\x02 \x01(\x03R\n" +
+	"unpinnedBy\"g\n" +
+	"\x10RetentionChanged\x124\n" +
+	"\tretention\x18\x01 \x01(\v2\x16.chatgrpc.v1.RetentionR\tretention\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x02 \x01(\x03R\tchangedBy\"j\n" +
+	"\x0eMessageDeleted\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x129\n" +
+	"\n" +
+	"deleted_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"\x8c\x01\n" +
+	"\vReadReceipt\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\x03R\tmessageId\x12\x10\n" +
+	"\x03seq\x18\x03 \x01(\x03R\x03seq\x123\n" +
+	"\aread_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\"t\n" +
+	"\x06Typing\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06typing\x18\x02 \x01(\bR\x06typing\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"k\n" +
+	"\x10ReactionsChanged\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x128\n" +
+	"\treactions\x18\x02 \x03(\v2\x1a.chatgrpc.v1.ReactionCountR\treactions\"?\n" +
 	"\x11CreateChatRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\";\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06public\x18\x02 \x01(\bR\x06public\";\n" +
 	"\x12CreateChatResponse\x12%\n" +
 	"\x04chat\x18\x01 \x01(\v2\x11.chatgrpc.v1.ChatR\x04chat\">\n" +
 	"\x13GetChatListResponse\x12'\n" +
-	"\x05chats\x18\x01 \x03(\v2\x11.chatgrpc.v1.ChatR\x05chats\"*\n" +
+	"\x05chats\x18\x01 \x03(\v2\x11.chatgrpc.v1.ChatR\x05chats\"\xa1\x02\n" +
 	"\x04Chat\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"$\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12)\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x15.chatgrpc.v1.ChatKindR\x04kind\x12 \n" +
+	"\fpeer_user_id\x18\x04 \x01(\x03R\n" +
+	"peerUserId\x12!\n" +
+	"\funread_count\x18\x05 \x01(\x03R\vunreadCount\x127\n" +
+	"\flast_message\x18\x06 \x01(\v2\x14.chatgrpc.v1.MessageR\vlastMessage\x124\n" +
+	"\tretention\x18\a \x01(\v2\x16.chatgrpc.v1.RetentionR\tretention\x12\x16\n" +
+	"\x06public\x18\b \x01(\bR\x06public\"p\n" +
+	"\tRetention\x12.\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x1a.chatgrpc.v1.RetentionModeR\x04mode\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
+	"ttlSeconds\"d\n" +
+	"\x13SetRetentionRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x124\n" +
+	"\tretention\x18\x02 \x01(\v2\x16.chatgrpc.v1.RetentionR\tretention\",\n" +
+	"\x11ExportChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\"*\n" +
+	"\x12ExportChatResponse\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\")\n" +
+	"\x11ImportChatRequest\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\"\xef\x01\n" +
+	"\x12ImportChatResponse\x12%\n" +
+	"\x04chat\x18\x01 \x01(\v2\x11.chatgrpc.v1.ChatR\x04chat\x12)\n" +
+	"\x10already_imported\x18\x02 \x01(\bR\x0falreadyImported\x12+\n" +
+	"\x11imported_messages\x18\x03 \x01(\x05R\x10importedMessages\x12)\n" +
+	"\x10skipped_messages\x18\x04 \x01(\x05R\x0fskippedMessages\x12/\n" +
+	"\x13missing_attachments\x18\x05 \x01(\x05R\x12missingAttachments\"@\n" +
+	"\x1cGetOrCreateDirectChatRequest\x12 \n" +
+	"\fpeer_user_id\x18\x01 \x01(\x03R\n" +
+	"peerUserId\"F\n" +
+	"\x1dGetOrCreateDirectChatResponse\x12%\n" +
+	"\x04chat\x18\x01 \x01(\v2\x11.chatgrpc.v1.ChatR\x04chat\"U\n" +
 	"\x12ConnectChatRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"^\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12/\n" +
+	"\x14last_seen_message_id\x18\x02 \x01(\x03R\x11lastSeenMessageId\"\xb8\x01\n" +
 	"\x12SendMessageRequest\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x1b\n" +
-	"\tsender_id\x18\x02 \x01(\x03R\bsenderId\x12\x12\n" +
-	"\x04text\x18\x03 \x01(\tR\x04text2\xb5\x02\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x1f\n" +
+	"\tsender_id\x18\x02 \x01(\x03B\x02\x18\x01R\bsenderId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12-\n" +
+	"\x13reply_to_message_id\x18\x04 \x01(\x03R\x10replyToMessageId\x12%\n" +
+	"\x0eattachment_ids\x18\x05 \x03(\x03R\rattachmentIds\"\x91\x01\n" +
+	"\x12GetMessagesRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\x03R\x06cursor\x124\n" +
+	"\tdirection\x18\x03 \x01(\x0e2\x16.chatgrpc.v1.DirectionR\tdirection\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"h\n" +
+	"\x13GetMessagesResponse\x120\n" +
+	"\bmessages\x18\x01 \x03(\v2\x14.chatgrpc.v1.MessageR\bmessages\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\x03R\n" +
+	"nextCursor\"\x99\x01\n" +
+	"\x06Member\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x127\n" +
+	"\tjoined_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\x12%\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x11.chatgrpc.v1.RoleR\x04role\x12\x16\n" +
+	"\x06handle\x18\x04 \x01(\tR\x06handle\"D\n" +
+	"\x10AddMemberRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"G\n" +
+	"\x13RemoveMemberRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"*\n" +
+	"\x0fJoinChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\"+\n" +
+	"\x10LeaveChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\"-\n" +
+	"\x12ListMembersRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\"D\n" +
+	"\x13ListMembersResponse\x12-\n" +
+	"\amembers\x18\x01 \x03(\v2\x13.chatgrpc.v1.MemberR\amembers\"o\n" +
+	"\x14SetMemberRoleRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12%\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x11.chatgrpc.v1.RoleR\x04role\"G\n" +
+	"\x12EditMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"5\n" +
+	"\x14DeleteMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\"2\n" +
+	"\x11PinMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\"4\n" +
+	"\x13UnpinMessageRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\"4\n" +
+	"\x19ListPinnedMessagesRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\"B\n" +
+	"\x1aListPinnedMessagesResponse\x12$\n" +
+	"\x04pins\x18\x01 \x03(\v2\x10.chatgrpc.v1.PinR\x04pins\"I\n" +
+	"\x12AddReactionRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x02 \x01(\tR\x05emoji\"L\n" +
+	"\x15RemoveReactionRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x02 \x01(\tR\x05emoji\"\x95\x01\n" +
+	"\x10GetThreadRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x03R\tmessageId\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\x03R\x06cursor\x124\n" +
+	"\tdirection\x18\x03 \x01(\x0e2\x16.chatgrpc.v1.DirectionR\tdirection\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x8e\x01\n" +
+	"\x11GetThreadResponse\x12(\n" +
+	"\x04root\x18\x01 \x01(\v2\x14.chatgrpc.v1.MessageR\x04root\x12.\n" +
+	"\areplies\x18\x02 \x03(\v2\x14.chatgrpc.v1.MessageR\areplies\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\x03R\n" +
+	"nextCursor\"I\n" +
+	"\x0fMarkReadRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\x03R\tmessageId\"F\n" +
+	"\x13NotifyTypingRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x16\n" +
+	"\x06typing\x18\x02 \x01(\bR\x06typing\"\x96\x01\n" +
+	"\bPresence\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x123\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1b.chatgrpc.v1.PresenceStatusR\x06status\x12<\n" +
+	"\flast_seen_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\"I\n" +
+	"\x12SetPresenceRequest\x123\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1b.chatgrpc.v1.PresenceStatusR\x06status\"/\n" +
+	"\x12GetPresenceRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\x03R\auserIds\"J\n" +
+	"\x13GetPresenceResponse\x123\n" +
+	"\tpresences\x18\x01 \x03(\v2\x15.chatgrpc.v1.PresenceR\tpresences\"1\n" +
+	"\x14WatchPresenceRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\x03R\auserIds\"\xf6\x02\n" +
+	"\x0eSessionRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\x03R\trequestId\x12:\n" +
+	"\tsubscribe\x18\x02 \x01(\v2\x1a.chatgrpc.v1.SubscribeChatH\x00R\tsubscribe\x12@\n" +
+	"\vunsubscribe\x18\x03 \x01(\v2\x1c.chatgrpc.v1.UnsubscribeChatH\x00R\vunsubscribe\x12D\n" +
+	"\fsend_message\x18\x04 \x01(\v2\x1f.chatgrpc.v1.SendMessageRequestH\x00R\vsendMessage\x12:\n" +
+	"\x06typing\x18\x05 \x01(\v2 .chatgrpc.v1.NotifyTypingRequestH\x00R\x06typing\x12;\n" +
+	"\tmark_read\x18\x06 \x01(\v2\x1c.chatgrpc.v1.MarkReadRequestH\x00R\bmarkReadB\b\n" +
+	"\x06action\"Y\n" +
+	"\rSubscribeChat\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12/\n" +
+	"\x14last_seen_message_id\x18\x02 \x01(\x03R\x11lastSeenMessageId\"*\n" +
+	"\x0fUnsubscribeChat\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\"y\n" +
+	"\x0fSessionResponse\x12.\n" +
+	"\x05event\x18\x01 \x01(\v2\x16.chatgrpc.v1.ChatEventH\x00R\x05event\x12+\n" +
+	"\x03ack\x18\x02 \x01(\v2\x17.chatgrpc.v1.SessionAckH\x00R\x03ackB\t\n" +
+	"\apayload\"\x85\x01\n" +
+	"\n" +
+	"SessionAck\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\x03R\trequestId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12.\n" +
+	"\amessage\x18\x04 \x01(\v2\x14.chatgrpc.v1.MessageR\amessage\"\xef\x01\n" +
+	"\x15SearchMessagesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x19\n" +
+	"\bchat_ids\x18\x02 \x03(\x03R\achatIds\x12\x1b\n" +
+	"\tsender_id\x18\x03 \x01(\x03R\bsenderId\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\x03R\x06cursor\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"X\n" +
+	"\fSearchResult\x12.\n" +
+	"\amessage\x18\x01 \x01(\v2\x14.chatgrpc.v1.MessageR\amessage\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\"n\n" +
+	"\x16SearchMessagesResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.chatgrpc.v1.SearchResultR\aresults\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\x03R\n" +
+	"nextCursor\"r\n" +
+	"\x17UploadAttachmentRequest\x127\n" +
+	"\x04info\x18\x01 \x01(\v2!.chatgrpc.v1.UploadAttachmentInfoH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"K\n" +
+	"\x14UploadAttachmentInfo\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\"c\n" +
+	"\x19DownloadAttachmentRequest\x12#\n" +
+	"\rattachment_id\x18\x01 \x01(\x03R\fattachmentId\x12!\n" +
+	"\fthumbnail_id\x18\x02 \x01(\x03R\vthumbnailId\"\xaf\x01\n" +
+	"\x1aDownloadAttachmentResponse\x129\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x17.chatgrpc.v1.AttachmentH\x00R\n" +
+	"attachment\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunk\x126\n" +
+	"\tthumbnail\x18\x03 \x01(\v2\x16.chatgrpc.v1.ThumbnailH\x00R\tthumbnailB\x06\n" +
+	"\x04data\"\xd7\x01\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\x03R\x06chatId\x12.\n" +
+	"\amessage\x18\x03 \x01(\v2\x14.chatgrpc.v1.MessageR\amessage\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\aread_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\"i\n" +
+	"\x18ListNotificationsRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\x03R\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vunread_only\x18\x03 \x01(\bR\n" +
+	"unreadOnly\"}\n" +
+	"\x19ListNotificationsResponse\x12?\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x19.chatgrpc.v1.NotificationR\rnotifications\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\x03R\n" +
+	"nextCursor\"F\n" +
+	"\x1bMarkNotificationReadRequest\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\x03R\x0enotificationId\"*\n" +
+	"\x10SetHandleRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\tR\x06handle*P\n" +
+	"\bChatKind\x12\x19\n" +
+	"\x15CHAT_KIND_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fCHAT_KIND_GROUP\x10\x01\x12\x14\n" +
+	"\x10CHAT_KIND_DIRECT\x10\x02*\x85\x01\n" +
+	"\rRetentionMode\x12\x1e\n" +
+	"\x1aRETENTION_MODE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16RETENTION_MODE_FOREVER\x10\x01\x12\x17\n" +
+	"\x13RETENTION_MODE_DAYS\x10\x02\x12\x1f\n" +
+	"\x1bRETENTION_MODE_DISAPPEARING\x10\x03*P\n" +
+	"\tDirection\x12\x19\n" +
+	"\x15DIRECTION_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fDIRECTION_OLDER\x10\x01\x12\x13\n" +
+	"\x0fDIRECTION_NEWER\x10\x02*a\n" +
+	"\x04Role\x12\x14\n" +
+	"\x10ROLE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"ROLE_OWNER\x10\x01\x12\x0e\n" +
+	"\n" +
+	"ROLE_ADMIN\x10\x02\x12\x0f\n" +
+	"\vROLE_MEMBER\x10\x03\x12\x12\n" +
+	"\x0eROLE_READ_ONLY\x10\x04*\x84\x01\n" +
+	"\x0ePresenceStatus\x12\x1f\n" +
+	"\x1bPRESENCE_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16PRESENCE_STATUS_ONLINE\x10\x01\x12\x18\n" +
+	"\x14PRESENCE_STATUS_AWAY\x10\x02\x12\x1b\n" +
+	"\x17PRESENCE_STATUS_OFFLINE\x10\x032\x9c\x16\n" +
 	"\vChatService\x12M\n" +
 	"\n" +
 	"CreateChat\x12\x1e.chatgrpc.v1.CreateChatRequest\x1a\x1f.chatgrpc.v1.CreateChatResponse\x12G\n" +
-	"\vGetChatList\x12\x16.google.protobuf.Empty\x1a .chatgrpc.v1.GetChatListResponse\x12F\n" +
-	"\vConnectChat\x12\x1f.chatgrpc.v1.ConnectChatRequest\x1a\x14.chatgrpc.v1.Message0\x01\x12F\n" +
-	"\vSendMessage\x12\x1f.chatgrpc.v1.SendMessageRequest\x1a\x16.google.protobuf.EmptyB8Z6github.com/Gilf4/grpcChat/protos/gen/go/chat/v1;chatv1b\x06proto3"
+	"\vGetChatList\x12\x16.google.protobuf.Empty\x1a .chatgrpc.v1.GetChatListResponse\x12n\n" +
+	"\x15GetOrCreateDirectChat\x12).chatgrpc.v1.GetOrCreateDirectChatRequest\x1a*.chatgrpc.v1.GetOrCreateDirectChatResponse\x12H\n" +
+	"\fSetRetention\x12 .chatgrpc.v1.SetRetentionRequest\x1a\x16.google.protobuf.Empty\x12O\n" +
+	"\n" +
+	"ExportChat\x12\x1e.chatgrpc.v1.ExportChatRequest\x1a\x1f.chatgrpc.v1.ExportChatResponse0\x01\x12O\n" +
+	"\n" +
+	"ImportChat\x12\x1e.chatgrpc.v1.ImportChatRequest\x1a\x1f.chatgrpc.v1.ImportChatResponse(\x01\x12H\n" +
+	"\vConnectChat\x12\x1f.chatgrpc.v1.ConnectChatRequest\x1a\x16.chatgrpc.v1.ChatEvent0\x01\x12H\n" +
+	"\aSession\x12\x1b.chatgrpc.v1.SessionRequest\x1a\x1c.chatgrpc.v1.SessionResponse(\x010\x01\x12@\n" +
+	"\fStreamEvents\x12\x16.google.protobuf.Empty\x1a\x16.chatgrpc.v1.ChatEvent0\x01\x12F\n" +
+	"\vSendMessage\x12\x1f.chatgrpc.v1.SendMessageRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\vEditMessage\x12\x1f.chatgrpc.v1.EditMessageRequest\x1a\x14.chatgrpc.v1.Message\x12J\n" +
+	"\rDeleteMessage\x12!.chatgrpc.v1.DeleteMessageRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\bMarkRead\x12\x1c.chatgrpc.v1.MarkReadRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\fNotifyTyping\x12 .chatgrpc.v1.NotifyTypingRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\vAddReaction\x12\x1f.chatgrpc.v1.AddReactionRequest\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\x0eRemoveReaction\x12\".chatgrpc.v1.RemoveReactionRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\n" +
+	"PinMessage\x12\x1e.chatgrpc.v1.PinMessageRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\fUnpinMessage\x12 .chatgrpc.v1.UnpinMessageRequest\x1a\x16.google.protobuf.Empty\x12e\n" +
+	"\x12ListPinnedMessages\x12&.chatgrpc.v1.ListPinnedMessagesRequest\x1a'.chatgrpc.v1.ListPinnedMessagesResponse\x12P\n" +
+	"\vGetMessages\x12\x1f.chatgrpc.v1.GetMessagesRequest\x1a .chatgrpc.v1.GetMessagesResponse\x12J\n" +
+	"\tGetThread\x12\x1d.chatgrpc.v1.GetThreadRequest\x1a\x1e.chatgrpc.v1.GetThreadResponse\x12Y\n" +
+	"\x0eSearchMessages\x12\".chatgrpc.v1.SearchMessagesRequest\x1a#.chatgrpc.v1.SearchMessagesResponse\x12b\n" +
+	"\x11ListNotifications\x12%.chatgrpc.v1.ListNotificationsRequest\x1a&.chatgrpc.v1.ListNotificationsResponse\x12X\n" +
+	"\x14MarkNotificationRead\x12(.chatgrpc.v1.MarkNotificationReadRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\tSetHandle\x12\x1d.chatgrpc.v1.SetHandleRequest\x1a\x16.google.protobuf.Empty\x12S\n" +
+	"\x10UploadAttachment\x12$.chatgrpc.v1.UploadAttachmentRequest\x1a\x17.chatgrpc.v1.Attachment(\x01\x12g\n" +
+	"\x12DownloadAttachment\x12&.chatgrpc.v1.DownloadAttachmentRequest\x1a'.chatgrpc.v1.DownloadAttachmentResponse0\x01\x12B\n" +
+	"\tAddMember\x12\x1d.chatgrpc.v1.AddMemberRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\fRemoveMember\x12 .chatgrpc.v1.RemoveMemberRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\bJoinChat\x12\x1c.chatgrpc.v1.JoinChatRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\tLeaveChat\x12\x1d.chatgrpc.v1.LeaveChatRequest\x1a\x16.google.protobuf.Empty\x12P\n" +
+	"\vListMembers\x12\x1f.chatgrpc.v1.ListMembersRequest\x1a .chatgrpc.v1.ListMembersResponse\x12J\n" +
+	"\rSetMemberRole\x12!.chatgrpc.v1.SetMemberRoleRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\vSetPresence\x12\x1f.chatgrpc.v1.SetPresenceRequest\x1a\x16.google.protobuf.Empty\x12P\n" +
+	"\vGetPresence\x12\x1f.chatgrpc.v1.GetPresenceRequest\x1a .chatgrpc.v1.GetPresenceResponse\x12K\n" +
+	"\rWatchPresence\x12!.chatgrpc.v1.WatchPresenceRequest\x1a\x15.chatgrpc.v1.Presence0\x01B8Z6github.com/Gilf4/grpcChat/protos/gen/go/chat/v1;chatv1b\x06proto3"
 
 var (
 	file_chat_v1_chat_proto_rawDescOnce sync.Once
@@ -422,33 +5306,239 @@ func file_chat_v1_chat_proto_rawDescGZIP() []byte {
 	return file_chat_v1_chat_proto_rawDescData
 }
 
-var file_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_chat_v1_chat_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_chat_v1_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
 var file_chat_v1_chat_proto_goTypes = []any{
-	(*Message)(nil),             // 0: chatgrpc.v1.Message
-	(*CreateChatRequest)(nil),   // 1: chatgrpc.v1.CreateChatRequest
-	(*CreateChatResponse)(nil),  // 2: chatgrpc.v1.CreateChatResponse
-	(*GetChatListResponse)(nil), // 3: chatgrpc.v1.GetChatListResponse
-	(*Chat)(nil),                // 4: chatgrpc.v1.Chat
-	(*ConnectChatRequest)(nil),  // 5: chatgrpc.v1.ConnectChatRequest
-	(*SendMessageRequest)(nil),  // 6: chatgrpc.v1.SendMessageRequest
-	(*emptypb.Empty)(nil),       // 7: google.protobuf.Empty
+	(ChatKind)(0),                         // 0: chatgrpc.v1.ChatKind
+	(RetentionMode)(0),                    // 1: chatgrpc.v1.RetentionMode
+	(Direction)(0),                        // 2: chatgrpc.v1.Direction
+	(Role)(0),                             // 3: chatgrpc.v1.Role
+	(PresenceStatus)(0),                   // 4: chatgrpc.v1.PresenceStatus
+	(*Message)(nil),                       // 5: chatgrpc.v1.Message
+	(*Attachment)(nil),                    // 6: chatgrpc.v1.Attachment
+	(*Thumbnail)(nil),                     // 7: chatgrpc.v1.Thumbnail
+	(*ReactionCount)(nil),                 // 8: chatgrpc.v1.ReactionCount
+	(*ChatEvent)(nil),                     // 9: chatgrpc.v1.ChatEvent
+	(*ThreadUpdated)(nil),                 // 10: chatgrpc.v1.ThreadUpdated
+	(*Mention)(nil),                       // 11: chatgrpc.v1.Mention
+	(*MemberJoined)(nil),                  // 12: chatgrpc.v1.MemberJoined
+	(*MemberLeft)(nil),                    // 13: chatgrpc.v1.MemberLeft
+	(*Pin)(nil),                           // 14: chatgrpc.v1.Pin
+	(*MessageUnpinned)(nil),               // 15: chatgrpc.v1.MessageUnpinned
+	(*RetentionChanged)(nil),              // 16: chatgrpc.v1.RetentionChanged
+	(*MessageDeleted)(nil),                // 17: chatgrpc.v1.MessageDeleted
+	(*ReadReceipt)(nil),                   // 18: chatgrpc.v1.ReadReceipt
+	(*Typing)(nil),                        // 19: chatgrpc.v1.Typing
+	(*ReactionsChanged)(nil),              // 20: chatgrpc.v1.ReactionsChanged
+	(*CreateChatRequest)(nil),             // 21: chatgrpc.v1.CreateChatRequest
+	(*CreateChatResponse)(nil),            // 22: chatgrpc.v1.CreateChatResponse
+	(*GetChatListResponse)(nil),           // 23: chatgrpc.v1.GetChatListResponse
+	(*Chat)(nil),                          // 24: chatgrpc.v1.Chat
+	(*Retention)(nil),                     // 25: chatgrpc.v1.Retention
+	(*SetRetentionRequest)(nil),           // 26: chatgrpc.v1.SetRetentionRequest
+	(*ExportChatRequest)(nil),             // 27: chatgrpc.v1.ExportChatRequest
+	(*ExportChatResponse)(nil),            // 28: chatgrpc.v1.ExportChatResponse
+	(*ImportChatRequest)(nil),             // 29: chatgrpc.v1.ImportChatRequest
+	(*ImportChatResponse)(nil),            // 30: chatgrpc.v1.ImportChatResponse
+	(*GetOrCreateDirectChatRequest)(nil),  // 31: chatgrpc.v1.GetOrCreateDirectChatRequest
+	(*GetOrCreateDirectChatResponse)(nil), // 32: chatgrpc.v1.GetOrCreateDirectChatResponse
+	(*ConnectChatRequest)(nil),            // 33: chatgrpc.v1.ConnectChatRequest
+	(*SendMessageRequest)(nil),            // 34: chatgrpc.v1.SendMessageRequest
+	(*GetMessagesRequest)(nil),            // 35: chatgrpc.v1.GetMessagesRequest
+	(*GetMessagesResponse)(nil),           // 36: chatgrpc.v1.GetMessagesResponse
+	(*Member)(nil),                        // 37: chatgrpc.v1.Member
+	(*AddMemberRequest)(nil),              // 38: chatgrpc.v1.AddMemberRequest
+	(*RemoveMemberRequest)(nil),           // 39: chatgrpc.v1.RemoveMemberRequest
+	(*JoinChatRequest)(nil),               // 40: chatgrpc.v1.JoinChatRequest
+	(*LeaveChatRequest)(nil),              // 41: chatgrpc.v1.LeaveChatRequest
+	(*ListMembersRequest)(nil),            // 42: chatgrpc.v1.ListMembersRequest
+	(*ListMembersResponse)(nil),           // 43: chatgrpc.v1.ListMembersResponse
+	(*SetMemberRoleRequest)(nil),          // 44: chatgrpc.v1.SetMemberRoleRequest
+	(*EditMessageRequest)(nil),            // 45: chatgrpc.v1.EditMessageRequest
+	(*DeleteMessageRequest)(nil),          // 46: chatgrpc.v1.DeleteMessageRequest
+	(*PinMessageRequest)(nil),             // 47: chatgrpc.v1.PinMessageRequest
+	(*UnpinMessageRequest)(nil),           // 48: chatgrpc.v1.UnpinMessageRequest
+	(*ListPinnedMessagesRequest)(nil),     // 49: chatgrpc.v1.ListPinnedMessagesRequest
+	(*ListPinnedMessagesResponse)(nil),    // 50: chatgrpc.v1.ListPinnedMessagesResponse
+	(*AddReactionRequest)(nil),            // 51: chatgrpc.v1.AddReactionRequest
+	(*RemoveReactionRequest)(nil),         // 52: chatgrpc.v1.RemoveReactionRequest
+	(*GetThreadRequest)(nil),              // 53: chatgrpc.v1.GetThreadRequest
+	(*GetThreadResponse)(nil),             // 54: chatgrpc.v1.GetThreadResponse
+	(*MarkReadRequest)(nil),               // 55: chatgrpc.v1.MarkReadRequest
+	(*NotifyTypingRequest)(nil),           // 56: chatgrpc.v1.NotifyTypingRequest
+	(*Presence)(nil),                      // 57: chatgrpc.v1.Presence
+	(*SetPresenceRequest)(nil),            // 58: chatgrpc.v1.SetPresenceRequest
+	(*GetPresenceRequest)(nil),            // 59: chatgrpc.v1.GetPresenceRequest
+	(*GetPresenceResponse)(nil),           // 60: chatgrpc.v1.GetPresenceResponse
+	(*WatchPresenceRequest)(nil),          // 61: chatgrpc.v1.WatchPresenceRequest
+	(*SessionRequest)(nil),                // 62: chatgrpc.v1.SessionRequest
+	(*SubscribeChat)(nil),                 // 63: chatgrpc.v1.SubscribeChat
+	(*UnsubscribeChat)(nil),               // 64: chatgrpc.v1.UnsubscribeChat
+	(*SessionResponse)(nil),               // 65: chatgrpc.v1.SessionResponse
+	(*SessionAck)(nil),                    // 66: chatgrpc.v1.SessionAck
+	(*SearchMessagesRequest)(nil),         // 67: chatgrpc.v1.SearchMessagesRequest
+	(*SearchResult)(nil),                  // 68: chatgrpc.v1.SearchResult
+	(*SearchMessagesResponse)(nil),        // 69: chatgrpc.v1.SearchMessagesResponse
+	(*UploadAttachmentRequest)(nil),       // 70: chatgrpc.v1.UploadAttachmentRequest
+	(*UploadAttachmentInfo)(nil),          // 71: chatgrpc.v1.UploadAttachmentInfo
+	(*DownloadAttachmentRequest)(nil),     // 72: chatgrpc.v1.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil),    // 73: chatgrpc.v1.DownloadAttachmentResponse
+	(*Notification)(nil),                  // 74: chatgrpc.v1.Notification
+	(*ListNotificationsRequest)(nil),      // 75: chatgrpc.v1.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),     // 76: chatgrpc.v1.ListNotificationsResponse
+	(*MarkNotificationReadRequest)(nil),   // 77: chatgrpc.v1.MarkNotificationReadRequest
+	(*SetHandleRequest)(nil),              // 78: chatgrpc.v1.SetHandleRequest
+	(*timestamppb.Timestamp)(nil),         // 79: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                 // 80: google.protobuf.Empty
 }
 var file_chat_v1_chat_proto_depIdxs = []int32{
-	4, // 0: chatgrpc.v1.CreateChatResponse.chat:type_name -> chatgrpc.v1.Chat
-	4, // 1: chatgrpc.v1.GetChatListResponse.chats:type_name -> chatgrpc.v1.Chat
-	1, // 2: chatgrpc.v1.ChatService.CreateChat:input_type -> chatgrpc.v1.CreateChatRequest
-	7, // 3: chatgrpc.v1.ChatService.GetChatList:input_type -> google.protobuf.Empty
-	5, // 4: chatgrpc.v1.ChatService.ConnectChat:input_type -> chatgrpc.v1.ConnectChatRequest
-	6, // 5: chatgrpc.v1.ChatService.SendMessage:input_type -> chatgrpc.v1.SendMessageRequest
-	2, // 6: chatgrpc.v1.ChatService.CreateChat:output_type -> chatgrpc.v1.CreateChatResponse
-	3, // 7: chatgrpc.v1.ChatService.GetChatList:output_type -> chatgrpc.v1.GetChatListResponse
-	0, // 8: chatgrpc.v1.ChatService.ConnectChat:output_type -> chatgrpc.v1.Message
-	7, // 9: chatgrpc.v1.ChatService.SendMessage:output_type -> google.protobuf.Empty
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	79,  // 0: chatgrpc.v1.Message.created_at:type_name -> google.protobuf.Timestamp
+	79,  // 1: chatgrpc.v1.Message.edited_at:type_name -> google.protobuf.Timestamp
+	8,   // 2: chatgrpc.v1.Message.reactions:type_name -> chatgrpc.v1.ReactionCount
+	79,  // 3: chatgrpc.v1.Message.last_reply_at:type_name -> google.protobuf.Timestamp
+	6,   // 4: chatgrpc.v1.Message.attachments:type_name -> chatgrpc.v1.Attachment
+	7,   // 5: chatgrpc.v1.Attachment.thumbnails:type_name -> chatgrpc.v1.Thumbnail
+	5,   // 6: chatgrpc.v1.ChatEvent.message_created:type_name -> chatgrpc.v1.Message
+	5,   // 7: chatgrpc.v1.ChatEvent.message_edited:type_name -> chatgrpc.v1.Message
+	17,  // 8: chatgrpc.v1.ChatEvent.message_deleted:type_name -> chatgrpc.v1.MessageDeleted
+	20,  // 9: chatgrpc.v1.ChatEvent.reactions_changed:type_name -> chatgrpc.v1.ReactionsChanged
+	18,  // 10: chatgrpc.v1.ChatEvent.read_receipt:type_name -> chatgrpc.v1.ReadReceipt
+	19,  // 11: chatgrpc.v1.ChatEvent.typing:type_name -> chatgrpc.v1.Typing
+	12,  // 12: chatgrpc.v1.ChatEvent.member_joined:type_name -> chatgrpc.v1.MemberJoined
+	13,  // 13: chatgrpc.v1.ChatEvent.member_left:type_name -> chatgrpc.v1.MemberLeft
+	11,  // 14: chatgrpc.v1.ChatEvent.mention:type_name -> chatgrpc.v1.Mention
+	14,  // 15: chatgrpc.v1.ChatEvent.message_pinned:type_name -> chatgrpc.v1.Pin
+	15,  // 16: chatgrpc.v1.ChatEvent.message_unpinned:type_name -> chatgrpc.v1.MessageUnpinned
+	16,  // 17: chatgrpc.v1.ChatEvent.retention_changed:type_name -> chatgrpc.v1.RetentionChanged
+	10,  // 18: chatgrpc.v1.ChatEvent.thread_updated:type_name -> chatgrpc.v1.ThreadUpdated
+	79,  // 19: chatgrpc.v1.ThreadUpdated.last_reply_at:type_name -> google.protobuf.Timestamp
+	5,   // 20: chatgrpc.v1.Mention.message:type_name -> chatgrpc.v1.Message
+	5,   // 21: chatgrpc.v1.Pin.message:type_name -> chatgrpc.v1.Message
+	79,  // 22: chatgrpc.v1.Pin.pinned_at:type_name -> google.protobuf.Timestamp
+	25,  // 23: chatgrpc.v1.RetentionChanged.retention:type_name -> chatgrpc.v1.Retention
+	79,  // 24: chatgrpc.v1.MessageDeleted.deleted_at:type_name -> google.protobuf.Timestamp
+	79,  // 25: chatgrpc.v1.ReadReceipt.read_at:type_name -> google.protobuf.Timestamp
+	79,  // 26: chatgrpc.v1.Typing.expires_at:type_name -> google.protobuf.Timestamp
+	8,   // 27: chatgrpc.v1.ReactionsChanged.reactions:type_name -> chatgrpc.v1.ReactionCount
+	24,  // 28: chatgrpc.v1.CreateChatResponse.chat:type_name -> chatgrpc.v1.Chat
+	24,  // 29: chatgrpc.v1.GetChatListResponse.chats:type_name -> chatgrpc.v1.Chat
+	0,   // 30: chatgrpc.v1.Chat.kind:type_name -> chatgrpc.v1.ChatKind
+	5,   // 31: chatgrpc.v1.Chat.last_message:type_name -> chatgrpc.v1.Message
+	25,  // 32: chatgrpc.v1.Chat.retention:type_name -> chatgrpc.v1.Retention
+	1,   // 33: chatgrpc.v1.Retention.mode:type_name -> chatgrpc.v1.RetentionMode
+	25,  // 34: chatgrpc.v1.SetRetentionRequest.retention:type_name -> chatgrpc.v1.Retention
+	24,  // 35: chatgrpc.v1.ImportChatResponse.chat:type_name -> chatgrpc.v1.Chat
+	24,  // 36: chatgrpc.v1.GetOrCreateDirectChatResponse.chat:type_name -> chatgrpc.v1.Chat
+	2,   // 37: chatgrpc.v1.GetMessagesRequest.direction:type_name -> chatgrpc.v1.Direction
+	5,   // 38: chatgrpc.v1.GetMessagesResponse.messages:type_name -> chatgrpc.v1.Message
+	79,  // 39: chatgrpc.v1.Member.joined_at:type_name -> google.protobuf.Timestamp
+	3,   // 40: chatgrpc.v1.Member.role:type_name -> chatgrpc.v1.Role
+	37,  // 41: chatgrpc.v1.ListMembersResponse.members:type_name -> chatgrpc.v1.Member
+	3,   // 42: chatgrpc.v1.SetMemberRoleRequest.role:type_name -> chatgrpc.v1.Role
+	14,  // 43: chatgrpc.v1.ListPinnedMessagesResponse.pins:type_name -> chatgrpc.v1.Pin
+	2,   // 44: chatgrpc.v1.GetThreadRequest.direction:type_name -> chatgrpc.v1.Direction
+	5,   // 45: chatgrpc.v1.GetThreadResponse.root:type_name -> chatgrpc.v1.Message
+	5,   // 46: chatgrpc.v1.GetThreadResponse.replies:type_name -> chatgrpc.v1.Message
+	4,   // 47: chatgrpc.v1.Presence.status:type_name -> chatgrpc.v1.PresenceStatus
+	79,  // 48: chatgrpc.v1.Presence.last_seen_at:type_name -> google.protobuf.Timestamp
+	4,   // 49: chatgrpc.v1.SetPresenceRequest.status:type_name -> chatgrpc.v1.PresenceStatus
+	57,  // 50: chatgrpc.v1.GetPresenceResponse.presences:type_name -> chatgrpc.v1.Presence
+	63,  // 51: chatgrpc.v1.SessionRequest.subscribe:type_name -> chatgrpc.v1.SubscribeChat
+	64,  // 52: chatgrpc.v1.SessionRequest.unsubscribe:type_name -> chatgrpc.v1.UnsubscribeChat
+	34,  // 53: chatgrpc.v1.SessionRequest.send_message:type_name -> chatgrpc.v1.SendMessageRequest
+	56,  // 54: chatgrpc.v1.SessionRequest.typing:type_name -> chatgrpc.v1.NotifyTypingRequest
+	55,  // 55: chatgrpc.v1.SessionRequest.mark_read:type_name -> chatgrpc.v1.MarkReadRequest
+	9,   // 56: chatgrpc.v1.SessionResponse.event:type_name -> chatgrpc.v1.ChatEvent
+	66,  // 57: chatgrpc.v1.SessionResponse.ack:type_name -> chatgrpc.v1.SessionAck
+	5,   // 58: chatgrpc.v1.SessionAck.message:type_name -> chatgrpc.v1.Message
+	79,  // 59: chatgrpc.v1.SearchMessagesRequest.from:type_name -> google.protobuf.Timestamp
+	79,  // 60: chatgrpc.v1.SearchMessagesRequest.to:type_name -> google.protobuf.Timestamp
+	5,   // 61: chatgrpc.v1.SearchResult.message:type_name -> chatgrpc.v1.Message
+	68,  // 62: chatgrpc.v1.SearchMessagesResponse.results:type_name -> chatgrpc.v1.SearchResult
+	71,  // 63: chatgrpc.v1.UploadAttachmentRequest.info:type_name -> chatgrpc.v1.UploadAttachmentInfo
+	6,   // 64: chatgrpc.v1.DownloadAttachmentResponse.attachment:type_name -> chatgrpc.v1.Attachment
+	7,   // 65: chatgrpc.v1.DownloadAttachmentResponse.thumbnail:type_name -> chatgrpc.v1.Thumbnail
+	5,   // 66: chatgrpc.v1.Notification.message:type_name -> chatgrpc.v1.Message
+	79,  // 67: chatgrpc.v1.Notification.created_at:type_name -> google.protobuf.Timestamp
+	79,  // 68: chatgrpc.v1.Notification.read_at:type_name -> google.protobuf.Timestamp
+	74,  // 69: chatgrpc.v1.ListNotificationsResponse.notifications:type_name -> chatgrpc.v1.Notification
+	21,  // 70: chatgrpc.v1.ChatService.CreateChat:input_type -> chatgrpc.v1.CreateChatRequest
+	80,  // 71: chatgrpc.v1.ChatService.GetChatList:input_type -> google.protobuf.Empty
+	31,  // 72: chatgrpc.v1.ChatService.GetOrCreateDirectChat:input_type -> chatgrpc.v1.GetOrCreateDirectChatRequest
+	26,  // 73: chatgrpc.v1.ChatService.SetRetention:input_type -> chatgrpc.v1.SetRetentionRequest
+	27,  // 74: chatgrpc.v1.ChatService.ExportChat:input_type -> chatgrpc.v1.ExportChatRequest
+	29,  // 75: chatgrpc.v1.ChatService.ImportChat:input_type -> chatgrpc.v1.ImportChatRequest
+	33,  // 76: chatgrpc.v1.ChatService.ConnectChat:input_type -> chatgrpc.v1.ConnectChatRequest
+	62,  // 77: chatgrpc.v1.ChatService.Session:input_type -> chatgrpc.v1.SessionRequest
+	80,  // 78: chatgrpc.v1.ChatService.StreamEvents:input_type -> google.protobuf.Empty
+	34,  // 79: chatgrpc.v1.ChatService.SendMessage:input_type -> chatgrpc.v1.SendMessageRequest
+	45,  // 80: chatgrpc.v1.ChatService.EditMessage:input_type -> chatgrpc.v1.EditMessageRequest
+	46,  // 81: chatgrpc.v1.ChatService.DeleteMessage:input_type -> chatgrpc.v1.DeleteMessageRequest
+	55,  // 82: chatgrpc.v1.ChatService.MarkRead:input_type -> chatgrpc.v1.MarkReadRequest
+	56,  // 83: chatgrpc.v1.ChatService.NotifyTyping:input_type -> chatgrpc.v1.NotifyTypingRequest
+	51,  // 84: chatgrpc.v1.ChatService.AddReaction:input_type -> chatgrpc.v1.AddReactionRequest
+	52,  // 85: chatgrpc.v1.ChatService.RemoveReaction:input_type -> chatgrpc.v1.RemoveReactionRequest
+	47,  // 86: chatgrpc.v1.ChatService.PinMessage:input_type -> chatgrpc.v1.PinMessageRequest
+	48,  // 87: chatgrpc.v1.ChatService.UnpinMessage:input_type -> chatgrpc.v1.UnpinMessageRequest
+	49,  // 88: chatgrpc.v1.ChatService.ListPinnedMessages:input_type -> chatgrpc.v1.ListPinnedMessagesRequest
+	35,  // 89: chatgrpc.v1.ChatService.GetMessages:input_type -> chatgrpc.v1.GetMessagesRequest
+	53,  // 90: chatgrpc.v1.ChatService.GetThread:input_type -> chatgrpc.v1.GetThreadRequest
+	67,  // 91: chatgrpc.v1.ChatService.SearchMessages:input_type -> chatgrpc.v1.SearchMessagesRequest
+	75,  // 92: chatgrpc.v1.ChatService.ListNotifications:input_type -> chatgrpc.v1.ListNotificationsRequest
+	77,  // 93: chatgrpc.v1.ChatService.MarkNotificationRead:input_type -> chatgrpc.v1.MarkNotificationReadRequest
+	78,  // 94: chatgrpc.v1.ChatService.SetHandle:input_type -> chatgrpc.v1.SetHandleRequest
+	70,  // 95: chatgrpc.v1.ChatService.UploadAttachment:input_type -> chatgrpc.v1.UploadAttachmentRequest
+	72,  // 96: chatgrpc.v1.ChatService.DownloadAttachment:input_type -> chatgrpc.v1.DownloadAttachmentRequest
+	38,  // 97: chatgrpc.v1.ChatService.AddMember:input_type -> chatgrpc.v1.AddMemberRequest
+	39,  // 98: chatgrpc.v1.ChatService.RemoveMember:input_type -> chatgrpc.v1.RemoveMemberRequest
+	40,  // 99: chatgrpc.v1.ChatService.JoinChat:input_type -> chatgrpc.v1.JoinChatRequest
+	41,  // 100: chatgrpc.v1.ChatService.LeaveChat:input_type -> chatgrpc.v1.LeaveChatRequest
+	42,  // 101: chatgrpc.v1.ChatService.ListMembers:input_type -> chatgrpc.v1.ListMembersRequest
+	44,  // 102: chatgrpc.v1.ChatService.SetMemberRole:input_type -> chatgrpc.v1.SetMemberRoleRequest
+	58,  // 103: chatgrpc.v1.ChatService.SetPresence:input_type -> chatgrpc.v1.SetPresenceRequest
+	59,  // 104: chatgrpc.v1.ChatService.GetPresence:input_type -> chatgrpc.v1.GetPresenceRequest
+	61,  // 105: chatgrpc.v1.ChatService.WatchPresence:input_type -> chatgrpc.v1.WatchPresenceRequest
+	22,  // 106: chatgrpc.v1.ChatService.CreateChat:output_type -> chatgrpc.v1.CreateChatResponse
+	23,  // 107: chatgrpc.v1.ChatService.GetChatList:output_type -> chatgrpc.v1.GetChatListResponse
+	32,  // 108: chatgrpc.v1.ChatService.GetOrCreateDirectChat:output_type -> chatgrpc.v1.GetOrCreateDirectChatResponse
+	80,  // 109: chatgrpc.v1.ChatService.SetRetention:output_type -> google.protobuf.Empty
+	28,  // 110: chatgrpc.v1.ChatService.ExportChat:output_type -> chatgrpc.v1.ExportChatResponse
+	30,  // 111: chatgrpc.v1.ChatService.ImportChat:output_type -> chatgrpc.v1.ImportChatResponse
+	9,   // 112: chatgrpc.v1.ChatService.ConnectChat:output_type -> chatgrpc.v1.ChatEvent
+	65,  // 113: chatgrpc.v1.ChatService.Session:output_type -> chatgrpc.v1.SessionResponse
+	9,   // 114: chatgrpc.v1.ChatService.StreamEvents:output_type -> chatgrpc.v1.ChatEvent
+	80,  // 115: chatgrpc.v1.ChatService.SendMessage:output_type -> google.protobuf.Empty
+	5,   // 116: chatgrpc.v1.ChatService.EditMessage:output_type -> chatgrpc.v1.Message
+	80,  // 117: chatgrpc.v1.ChatService.DeleteMessage:output_type -> google.protobuf.Empty
+	80,  // 118: chatgrpc.v1.ChatService.MarkRead:output_type -> google.protobuf.Empty
+	80,  // 119: chatgrpc.v1.ChatService.NotifyTyping:output_type -> google.protobuf.Empty
+	80,  // 120: chatgrpc.v1.ChatService.AddReaction:output_type -> google.protobuf.Empty
+	80,  // 121: chatgrpc.v1.ChatService.RemoveReaction:output_type -> google.protobuf.Empty
+	80,  // 122: chatgrpc.v1.ChatService.PinMessage:output_type -> google.protobuf.Empty
+	80,  // 123: chatgrpc.v1.ChatService.UnpinMessage:output_type -> google.protobuf.Empty
+	50,  // 124: chatgrpc.v1.ChatService.ListPinnedMessages:output_type -> chatgrpc.v1.ListPinnedMessagesResponse
+	36,  // 125: chatgrpc.v1.ChatService.GetMessages:output_type -> chatgrpc.v1.GetMessagesResponse
+	54,  // 126: chatgrpc.v1.ChatService.GetThread:output_type -> chatgrpc.v1.GetThreadResponse
+	69,  // 127: chatgrpc.v1.ChatService.SearchMessages:output_type -> chatgrpc.v1.SearchMessagesResponse
+	76,  // 128: chatgrpc.v1.ChatService.ListNotifications:output_type -> chatgrpc.v1.ListNotificationsResponse
+	80,  // 129: chatgrpc.v1.ChatService.MarkNotificationRead:output_type -> google.protobuf.Empty
+	80,  // 130: chatgrpc.v1.ChatService.SetHandle:output_type -> google.protobuf.Empty
+	6,   // 131: chatgrpc.v1.ChatService.UploadAttachment:output_type -> chatgrpc.v1.Attachment
+	73,  // 132: chatgrpc.v1.ChatService.DownloadAttachment:output_type -> chatgrpc.v1.DownloadAttachmentResponse
+	80,  // 133: chatgrpc.v1.ChatService.AddMember:output_type -> google.protobuf.Empty
+	80,  // 134: chatgrpc.v1.ChatService.RemoveMember:output_type -> google.protobuf.Empty
+	80,  // 135: chatgrpc.v1.ChatService.JoinChat:output_type -> google.protobuf.Empty
+	80,  // 136: chatgrpc.v1.ChatService.LeaveChat:output_type -> google.protobuf.Empty
+	43,  // 137: chatgrpc.v1.ChatService.ListMembers:output_type -> chatgrpc.v1.ListMembersResponse
+	80,  // 138: chatgrpc.v1.ChatService.SetMemberRole:output_type -> google.protobuf.Empty
+	80,  // 139: chatgrpc.v1.ChatService.SetPresence:output_type -> google.protobuf.Empty
+	60,  // 140: chatgrpc.v1.ChatService.GetPresence:output_type -> chatgrpc.v1.GetPresenceResponse
+	57,  // 141: chatgrpc.v1.ChatService.WatchPresence:output_type -> chatgrpc.v1.Presence
+	106, // [106:142] is the sub-list for method output_type
+	70,  // [70:106] is the sub-list for method input_type
+	70,  // [70:70] is the sub-list for extension type_name
+	70,  // [70:70] is the sub-list for extension extendee
+	0,   // [0:70] is the sub-list for field type_name
 }
 
 func init() { file_chat_v1_chat_proto_init() }
@@ -456,18 +5546,54 @@ func file_chat_v1_chat_proto_init() {
 	if File_chat_v1_chat_proto != nil {
 		return
 	}
+	file_chat_v1_chat_proto_msgTypes[4].OneofWrappers = []any{
+		(*ChatEvent_MessageCreated)(nil),
+		(*ChatEvent_MessageEdited)(nil),
+		(*ChatEvent_MessageDeleted)(nil),
+		(*ChatEvent_ReactionsChanged)(nil),
+		(*ChatEvent_ReadReceipt)(nil),
+		(*ChatEvent_Typing)(nil),
+		(*ChatEvent_MemberJoined)(nil),
+		(*ChatEvent_MemberLeft)(nil),
+		(*ChatEvent_Mention)(nil),
+		(*ChatEvent_MessagePinned)(nil),
+		(*ChatEvent_MessageUnpinned)(nil),
+		(*ChatEvent_RetentionChanged)(nil),
+		(*ChatEvent_ThreadUpdated)(nil),
+	}
+	file_chat_v1_chat_proto_msgTypes[57].OneofWrappers = []any{
+		(*SessionRequest_Subscribe)(nil),
+		(*SessionRequest_Unsubscribe)(nil),
+		(*SessionRequest_SendMessage)(nil),
+		(*SessionRequest_Typing)(nil),
+		(*SessionRequest_MarkRead)(nil),
+	}
+	file_chat_v1_chat_proto_msgTypes[60].OneofWrappers = []any{
+		(*SessionResponse_Event)(nil),
+		(*SessionResponse_Ack)(nil),
+	}
+	file_chat_v1_chat_proto_msgTypes[65].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Info)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
+	file_chat_v1_chat_proto_msgTypes[68].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Attachment)(nil),
+		(*DownloadAttachmentResponse_Chunk)(nil),
+		(*DownloadAttachmentResponse_Thumbnail)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chat_v1_chat_proto_rawDesc), len(file_chat_v1_chat_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      5,
+			NumMessages:   74,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_chat_v1_chat_proto_goTypes,
 		DependencyIndexes: file_chat_v1_chat_proto_depIdxs,
+		EnumInfos:         file_chat_v1_chat_proto_enumTypes,
 		MessageInfos:      file_chat_v1_chat_proto_msgTypes,
 	}.Build()
 	File_chat_v1_chat_proto = out.File